@@ -0,0 +1,62 @@
+package ggpool
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+)
+
+//Backoff is a configuration of retries of background Object creation (see Config.Backoff)
+type Backoff struct {
+	//Delay before the first retry. Can be 0 - in this case failed background creation is not retried.
+	Initial time.Duration
+
+	//Max delay between retries. Can be 0 - in this case delay is not limited.
+	Max time.Duration
+
+	//Factor the delay is multiplied by after each failed retry. Can be 0 - in this case 2 is used.
+	Multiplier float64
+
+	//Fraction of the delay which is randomized, from 0 to 1.
+	//E.g. if Jitter is 0.2 then the delay is randomly reduced by up to 20% to spread retries of several pools.
+	Jitter float64
+}
+
+func (b Backoff) validate() error {
+
+	if b.Initial < 0 || b.Max < 0 {
+		return errors.New("backoff delay value must not be negative")
+	}
+
+	if b.Multiplier != 0 && b.Multiplier < 1 {
+		return errors.New("backoff multiplier value must not be less than 1")
+	}
+
+	if b.Jitter < 0 || b.Jitter > 1 {
+		return errors.New("backoff jitter value must be from 0 to 1")
+	}
+
+	return nil
+}
+
+//delay returns delay before retry attempt (starting from 1)
+func (b Backoff) delay(attempt int) time.Duration {
+	multiplier := b.Multiplier
+	if multiplier == 0 {
+		multiplier = 2
+	}
+
+	delay := float64(b.Initial) * math.Pow(multiplier, float64(attempt-1))
+
+	if b.Max > 0 && delay > float64(b.Max) {
+		delay = float64(b.Max)
+	}
+
+	return jitter(time.Duration(delay), b.Jitter)
+}
+
+//jitter randomly reduces duration by up to fraction of it
+func jitter(duration time.Duration, fraction float64) time.Duration {
+	return duration - time.Duration(float64(duration)*fraction*rand.Float64())
+}
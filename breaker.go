@@ -0,0 +1,128 @@
+package ggpool
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+//CircuitState is state of pool circuit breaker (see Config.CircuitBreaker)
+type CircuitState int
+
+const (
+	//CircuitClosed means that Objects are created normally
+	CircuitClosed CircuitState = iota
+	//CircuitOpen means that Object creation is suspended after consecutive factory failures
+	CircuitOpen
+	//CircuitHalfOpen means that a trial Object creation is allowed to check if factory has recovered
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+//CircuitBreaker is a configuration of circuit breaker around the factory (see Config.CircuitBreaker)
+type CircuitBreaker struct {
+	//Number of consecutive factory failures which opens the circuit.
+	//Can be 0 - in this case circuit breaker is disabled.
+	FailureThreshold int
+
+	//Duration the circuit stays open. After that one trial Object creation is allowed:
+	//if it succeeds then the circuit is closed, otherwise it is opened again.
+	OpenTimeout time.Duration
+}
+
+func (c CircuitBreaker) validate() error {
+
+	if c.FailureThreshold < 0 {
+		return errors.New("circuit breaker failure threshold value must not be negative")
+	}
+
+	if c.FailureThreshold > 0 && c.OpenTimeout <= 0 {
+		return errors.New("please specify circuit breaker OpenTimeout")
+	}
+
+	return nil
+}
+
+type circuitBreaker struct {
+	sync.Mutex
+	config         CircuitBreaker
+	state          CircuitState
+	failures       int
+	openedTime     time.Time
+	isTrialRunning bool
+}
+
+func newCircuitBreaker(config CircuitBreaker) *circuitBreaker {
+	return &circuitBreaker{
+		config: config,
+		state:  CircuitClosed,
+	}
+}
+
+//allow returns true if factory can be called
+func (b *circuitBreaker) allow() bool {
+	b.Lock()
+	defer b.Unlock()
+
+	b.update()
+
+	switch b.state {
+	case CircuitOpen:
+		return false
+	case CircuitHalfOpen:
+		if b.isTrialRunning {
+			return false
+		}
+		b.isTrialRunning = true
+	}
+	return true
+}
+
+func (b *circuitBreaker) success() {
+	b.Lock()
+	defer b.Unlock()
+
+	b.state = CircuitClosed
+	b.failures = 0
+	b.isTrialRunning = false
+}
+
+func (b *circuitBreaker) failure() {
+	b.Lock()
+	defer b.Unlock()
+
+	b.failures++
+	b.isTrialRunning = false
+
+	if b.config.FailureThreshold > 0 && (b.state == CircuitHalfOpen || b.failures >= b.config.FailureThreshold) {
+		b.state = CircuitOpen
+		b.openedTime = time.Now().UTC()
+	}
+}
+
+func (b *circuitBreaker) currentState() CircuitState {
+	b.Lock()
+	defer b.Unlock()
+
+	b.update()
+
+	return b.state
+}
+
+//update switches open circuit to half-open when OpenTimeout is exceeded
+func (b *circuitBreaker) update() {
+	if b.state == CircuitOpen && time.Now().UTC().Sub(b.openedTime) >= b.config.OpenTimeout {
+		b.state = CircuitHalfOpen
+	}
+}
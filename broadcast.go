@@ -0,0 +1,40 @@
+package ggpool
+
+import "sync"
+
+//errorBroadcast delivers an error to all subscribers which are waiting for it
+type errorBroadcast struct {
+	sync.Mutex
+	next *broadcastError
+}
+
+//broadcastError is an error which is published to subscribers when done is closed
+type broadcastError struct {
+	done chan struct{}
+	err  error
+}
+
+func newErrorBroadcast() *errorBroadcast {
+	return &errorBroadcast{
+		next: &broadcastError{done: make(chan struct{})},
+	}
+}
+
+//subscribe returns the next published error
+func (b *errorBroadcast) subscribe() *broadcastError {
+	b.Lock()
+	defer b.Unlock()
+
+	return b.next
+}
+
+//publish delivers err to all current subscribers
+func (b *errorBroadcast) publish(err error) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.next.err = err
+	close(b.next.done)
+
+	b.next = &broadcastError{done: make(chan struct{})}
+}
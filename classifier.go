@@ -0,0 +1,24 @@
+package ggpool
+
+//ErrorAction is a decision what to do with borrowed Object which has failed with an error
+type ErrorAction int
+
+const (
+	//ActionRelease means that Object is still usable and is returned to pool
+	ActionRelease ErrorAction = iota
+	//ActionDestroy means that Object is broken and is destroyed
+	ActionDestroy
+	//ActionQuarantine means that Object is kept out of pool for Config.QuarantineDuration and then it is validated and returned to pool
+	ActionQuarantine
+)
+
+//ErrorClassifier decides what to do with borrowed Object which has failed with err (see Config.ErrorClassifier)
+type ErrorClassifier func(err error) ErrorAction
+
+//classify returns action for Object which has failed with err. All errors are fatal if classifier is not specified
+func (c ErrorClassifier) classify(err error) ErrorAction {
+	if c == nil {
+		return ActionDestroy
+	}
+	return c(err)
+}
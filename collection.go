@@ -1,86 +1,168 @@
 package ggpool
 
 import (
-	"reflect"
+	"container/list"
+	"fmt"
 	"sync"
+	"time"
 )
 
-type collection struct {
+//errDuplicateItem is returned when factory creates an object which is already in pool
+var errDuplicateItem = fmt.Errorf("%w - factory must create distinct objects", ErrInvalidObject)
+
+type collection[T comparable] struct {
 	sync.RWMutex
-	allItems  map[uintptr]*item
-	idleItems map[uintptr]*item
-	isClosed  bool
+	allItems map[T]*item[T]
+	//idleItems are elements of idleList which is ordered by release time, the most recently released item is at the back
+	idleItems        map[T]*list.Element
+	idleList         *list.List
+	idleStrategy     IdleStrategy
+	abandonedItems   map[T]time.Time
+	quarantinedItems map[T]time.Time
+	//waiters is a queue of Get calls which are waiting for an item, the longest waiting one is at the front
+	waiters  *list.List
+	isClosed bool
 }
 
-func newCollection() *collection {
-	return &collection{
-		allItems:  make(map[uintptr]*item),
-		idleItems: make(map[uintptr]*item),
-		isClosed:  false,
-	}
+//waiter is a Get call which is waiting for an item
+type waiter[T comparable] struct {
+	//itemCh receives item which is handed out to the waiter
+	itemCh chan *item[T]
+	//element is nil when waiter is not in queue
+	element *list.Element
 }
 
-func getObjectKey(object *interface{}) uintptr {
-	return reflect.ValueOf(object).Pointer()
+func newCollection[T comparable](idleStrategy IdleStrategy) *collection[T] {
+	return &collection[T]{
+		allItems:         make(map[T]*item[T]),
+		idleItems:        make(map[T]*list.Element),
+		idleList:         list.New(),
+		idleStrategy:     idleStrategy,
+		abandonedItems:   make(map[T]time.Time),
+		quarantinedItems: make(map[T]time.Time),
+		waiters:          list.New(),
+		isClosed:         false,
+	}
 }
 
-func (c *collection) close() {
+func (c *collection[T]) close() {
 	c.Lock()
 	defer c.Unlock()
 
 	c.isClosed = true
 }
 
-func (c *collection) len() int {
+func (c *collection[T]) len() int {
 	c.RLock()
 	defer c.RUnlock()
 
 	return len(c.allItems)
 }
 
-func (c *collection) lenIdle() int {
+func (c *collection[T]) lenIdle() int {
 	c.RLock()
 	defer c.RUnlock()
 
 	return len(c.idleItems)
 }
 
-func (c *collection) acquire() *item {
+func (c *collection[T]) lenQuarantined() int {
+	c.RLock()
+	defer c.RUnlock()
+
+	return len(c.quarantinedItems)
+}
+
+//lenBorrowed returns number of items which are neither idle nor quarantined
+func (c *collection[T]) lenBorrowed() int {
+	c.RLock()
+	defer c.RUnlock()
+
+	return len(c.allItems) - len(c.idleItems) - len(c.quarantinedItems)
+}
+
+func (c *collection[T]) lenWaiting() int {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.waiters.Len()
+}
+
+//acquireOrWait takes idle item chosen by idle strategy. If there are no idle items then a new waiter is queued
+func (c *collection[T]) acquireOrWait() (*item[T], *waiter[T]) {
 	c.Lock()
 	defer c.Unlock()
 
-	for key, item := range c.idleItems {
-		delete(c.idleItems, key)
-		return item
+	if item := c.takeIdle(c.idleStrategy.pick(c.idleList)); item != nil {
+		return item, nil
 	}
-	return nil
+
+	waiter := &waiter[T]{itemCh: make(chan *item[T], 1)}
+	waiter.element = c.waiters.PushBack(waiter)
+
+	return nil, waiter
 }
 
-func (c *collection) acquireAll() []*item {
+//cancelWait removes waiter from queue. It returns false if an item has been already handed out to the waiter
+func (c *collection[T]) cancelWait(waiter *waiter[T]) bool {
 	c.Lock()
 	defer c.Unlock()
 
-	var res []*item
+	if waiter.element == nil {
+		return false
+	}
+
+	c.waiters.Remove(waiter.element)
+	waiter.element = nil
 
-	for key, item := range c.idleItems {
-		delete(c.idleItems, key)
-		res = append(res, item)
+	return true
+}
+
+//acquireOldest takes the least recently released idle item. It returns nil if there are no idle items
+func (c *collection[T]) acquireOldest() *item[T] {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.takeIdle(c.idleList.Front())
+}
+
+//acquireAll takes all idle items, the least recently released item is the first
+func (c *collection[T]) acquireAll() []*item[T] {
+	c.Lock()
+	defer c.Unlock()
+
+	var res []*item[T]
+
+	for element := c.idleList.Front(); element != nil; element = c.idleList.Front() {
+		res = append(res, c.takeIdle(element))
 	}
 	return res
 }
 
-func (c *collection) get(key uintptr) *item {
+//takeIdle removes element from idle items. It must be called under lock
+func (c *collection[T]) takeIdle(element *list.Element) *item[T] {
+	if element == nil {
+		return nil
+	}
+
+	item := c.idleList.Remove(element).(*item[T])
+	delete(c.idleItems, item.value)
+
+	return item
+}
+
+func (c *collection[T]) get(key T) *item[T] {
 	c.RLock()
 	defer c.RUnlock()
 
 	return c.allItems[key]
 }
 
-func (c *collection) getAll() []*item {
+func (c *collection[T]) getAll() []*item[T] {
 	c.Lock()
 	defer c.Unlock()
 
-	var res []*item
+	var res []*item[T]
 
 	for _, item := range c.allItems {
 		res = append(res, item)
@@ -88,30 +170,195 @@ func (c *collection) getAll() []*item {
 	return res
 }
 
-func (c *collection) put(key uintptr, value *item) bool {
+func (c *collection[T]) getBorrowed() []*item[T] {
+	c.RLock()
+	defer c.RUnlock()
+
+	var res []*item[T]
+
+	for key, item := range c.allItems {
+		_, isIdle := c.idleItems[key]
+		_, isQuarantined := c.quarantinedItems[key]
+
+		if !isIdle && !isQuarantined {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+//put adds item to collection. It returns false if collection is closed and error if there is an item with the same key
+func (c *collection[T]) put(key T, value *item[T]) (bool, error) {
 	c.Lock()
 	defer c.Unlock()
 
-	if !c.isClosed {
-		c.allItems[key] = value
+	if c.isClosed {
+		return false, nil
+	}
+
+	if _, ok := c.allItems[key]; ok {
+		return false, errDuplicateItem
 	}
 
-	return !c.isClosed
+	c.allItems[key] = value
+
+	return true, nil
 }
 
-func (c *collection) release(key uintptr) {
+//release hands item out to the longest waiting Get call or makes it idle if there are no waiters.
+//Idle item is put in order of its release time, so an item which is returned by pool maintenance keeps its position
+func (c *collection[T]) release(key T) {
+	c.Lock()
+	defer c.Unlock()
+
+	if _, ok := c.idleItems[key]; ok {
+		return
+	}
+
+	//item could be removed concurrently
+	item, ok := c.allItems[key]
+
+	if !ok {
+		return
+	}
+
+	//the longest waiting Get call takes the item, so it doesn't become idle
+	if element := c.waiters.Front(); element != nil {
+		waiter := c.waiters.Remove(element).(*waiter[T])
+		waiter.element = nil
+		waiter.itemCh <- item
+		return
+	}
+
+	c.idleItems[key] = c.insertIdle(item)
+}
+
+//insertIdle puts item in idle list in order of release time. It must be called under lock
+func (c *collection[T]) insertIdle(value *item[T]) *list.Element {
+	element := c.idleList.Back()
+
+	for element != nil && element.Value.(*item[T]).releasedTime.After(value.releasedTime) {
+		element = element.Prev()
+	}
+
+	if element == nil {
+		return c.idleList.PushFront(value)
+	}
+	return c.idleList.InsertAfter(value, element)
+}
+
+//remove removes item from collection. It returns nil if there is no such item
+func (c *collection[T]) remove(key T) *item[T] {
 	c.Lock()
 	defer c.Unlock()
 
 	item := c.allItems[key]
 
-	c.idleItems[key] = item
+	delete(c.allItems, key)
+	c.takeIdle(c.idleItems[key])
+	delete(c.quarantinedItems, key)
+
+	return item
+}
+
+//replace swaps item which is not idle with a new one. It returns false if the old item has been removed or collection is closed
+//and error if there is an item with the same key as the new one
+func (c *collection[T]) replace(oldKey T, key T, value *item[T]) (bool, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	if _, ok := c.allItems[oldKey]; !ok || c.isClosed {
+		return false, nil
+	}
+
+	if _, ok := c.allItems[key]; ok {
+		return false, errDuplicateItem
+	}
+
+	delete(c.allItems, oldKey)
+	c.takeIdle(c.idleItems[oldKey])
+	c.allItems[key] = value
+
+	return true, nil
 }
 
-func (c *collection) remove(key uintptr) {
+//abandon removes borrowed item from collection and remembers it until it is reclaimed
+func (c *collection[T]) abandon(key T) bool {
 	c.Lock()
 	defer c.Unlock()
 
+	if _, ok := c.allItems[key]; !ok {
+		return false
+	}
+
+	if _, ok := c.idleItems[key]; ok {
+		return false
+	}
+
 	delete(c.allItems, key)
-	delete(c.idleItems, key)
+	c.abandonedItems[key] = time.Now().UTC()
+
+	return true
+}
+
+//reclaim forgets abandoned item. It returns false if item is not abandoned
+func (c *collection[T]) reclaim(key T) bool {
+	c.Lock()
+	defer c.Unlock()
+
+	if _, ok := c.abandonedItems[key]; !ok {
+		return false
+	}
+
+	delete(c.abandonedItems, key)
+
+	return true
+}
+
+//forgetAbandoned forgets items which have been abandoned before deadline, so they are not kept forever if they are never released
+func (c *collection[T]) forgetAbandoned(deadline time.Time) {
+	c.Lock()
+	defer c.Unlock()
+
+	for key, abandonedTime := range c.abandonedItems {
+		if abandonedTime.Before(deadline) {
+			delete(c.abandonedItems, key)
+		}
+	}
+}
+
+//quarantine keeps borrowed item out of idle items until the given time
+func (c *collection[T]) quarantine(key T, until time.Time) bool {
+	c.Lock()
+	defer c.Unlock()
+
+	if _, ok := c.allItems[key]; !ok {
+		return false
+	}
+
+	if _, ok := c.idleItems[key]; ok {
+		return false
+	}
+
+	c.quarantinedItems[key] = until
+
+	return true
+}
+
+//unquarantine takes items which quarantine ends before deadline. The items are neither idle nor quarantined after that
+func (c *collection[T]) unquarantine(deadline time.Time) []*item[T] {
+	c.Lock()
+	defer c.Unlock()
+
+	var res []*item[T]
+
+	for key, until := range c.quarantinedItems {
+		if until.After(deadline) {
+			continue
+		}
+
+		delete(c.quarantinedItems, key)
+		res = append(res, c.allItems[key])
+	}
+	return res
 }
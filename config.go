@@ -7,6 +7,9 @@ import (
 
 // Config is a pool configuration
 type Config struct {
+	//Pool name. It is used to label metrics (see Metrics).
+	Name string
+
 	//Pool capacity.
 	Capacity int
 
@@ -16,37 +19,222 @@ type Config struct {
 
 	//Duration of pool Object lifetime.
 	//When the object lifetime expires method Object.Destroy() is called. Can be 0 - in this case item doesn't have lifetime limitation.
+	//
+	//Deprecated: use MaxIdleTime. ItemLifetime is used as MaxIdleTime if MaxIdleTime is not specified.
 	ItemLifetime time.Duration
 
+	//Maximum duration which Object may stay idle in pool. It is measured from the moment the Object is released.
+	//Idle Object is destroyed when it is exceeded. Can be 0 - in this case idle time is not limited.
+	MaxIdleTime time.Duration
+
+	//Maximum duration of Object life. It is measured from the moment the Object is created.
+	//Idle Object is destroyed when it is exceeded, borrowed one is destroyed on Release. Can be 0 - in this case Object life is not limited.
+	MaxLifetime time.Duration
+
+	//Duration before MaxLifetime expiration when idle Object is refreshed. The replacement Object is created first,
+	//then it takes the place of the old Object which is destroyed after that, so pool length does not drop while Objects are refreshed.
+	//If the replacement cannot be created then the old Object stays in pool until it expires. Can be 0 - in this case Objects are not refreshed.
+	//It must be less than MaxLifetime and it is reduced by LifetimeJitter in proportion to Object lifetime.
+	//Replacements are created by the maintenance routine one by one, use MaxExpirationsPerCheck to limit the number of them per check.
+	RefreshAhead time.Duration
+
+	//Maximum number of idle Objects which are replaced concurrently by Pool.Invalidate(). Can be 0 - in this case Objects are replaced one by one.
+	InvalidateConcurrency int
+
+	//Fraction of MaxIdleTime and MaxLifetime which is randomized per Object, from 0 to 1.
+	//E.g. if LifetimeJitter is 0.1 then the limits of each Object are randomly reduced by up to 10%,
+	//so Objects created at the same time do not expire at the same time.
+	LifetimeJitter float64
+
+	//Maximum number of expired Objects which are destroyed and Objects which are refreshed (see RefreshAhead) per ItemLifetimeCheckPeriod.
+	//The rest of Objects are handled by the following checks. Can be 0 - in this case all Objects are handled at once.
+	MaxExpirationsPerCheck int
+
+	//Create MinCapacity Objects in NewPool() before it returns (see Pool.WaitReady()).
+	//Creation is limited by Timeout if it is specified. NewPool() returns ReadyError if the pool is not ready.
+	PrefillOnStart bool
+
 	//Item lifetime check period.
-	//This means how often pool will check that the object lifetime is expired. If ItemLifetime is 0 then this setting is ignored
+	//This means how often pool will check that the object idle time or lifetime is expired, test idle objects (see TestWhileIdle) and detect leaks (see LeakThreshold)
+	//and abandoned objects (see MaxBorrowDuration). If none of these settings is specified then this setting is ignored
 	ItemLifetimeCheckPeriod time.Duration
 
 	//The timeout period of obtaining a item from the pool (Pool.Get()).
-	//If the timeout is exceeded the pool will return TimeoutError error.
+	//If the timeout is exceeded the pool will return ErrTimeout error.
 	Timeout time.Duration
 
+	//Order in which idle Objects are handed out by Pool.Get(). The default is IdleLIFO.
+	IdleStrategy IdleStrategy
+
+	//By default factory error is returned to all Pool.Get() calls which are waiting for an Object.
+	//If this setting is true then Pool.Get() keeps waiting for a released Object until Timeout is exceeded.
+	KeepWaitingOnFactoryError bool
+
+	//Retries of failed background Object creation which keeps MinCapacity.
+	Backoff Backoff
+
+	//Circuit breaker around the factory. While the circuit is open Pool.Get() fails fast with ErrCircuitOpen
+	//instead of calling the factory.
+	CircuitBreaker CircuitBreaker
+
+	//Validate Object on Pool.Get() if it implements Validator interface.
+	//Invalid object is destroyed and Pool.Get() tries to get another one.
+	TestOnBorrow bool
+
+	//Validate Object on Pool.Release() if it implements Validator interface.
+	//Invalid object is destroyed instead of returning to pool.
+	TestOnReturn bool
+
+	//Validate idle Objects which implement Validator interface every ItemLifetimeCheckPeriod.
+	//Invalid objects are destroyed and replaced to keep MinCapacity.
+	TestWhileIdle bool
+
+	//Max number of idle Objects validated per check. Can be 0 - in this case all idle objects are validated.
+	IdleTestsPerCheck int
+
+	//Timeout of idle Object validation. Can be 0 - in this case validation time is not limited.
+	IdleTestTimeout time.Duration
+
+	//Receiver of pool metrics. Can be nil - in this case metrics are not recorded.
+	//OpenMetricsRecorder can be used to expose metrics of one or several pools via HTTP.
+	Metrics MetricsRecorder
+
+	//Record stack trace of Pool.Get() caller for each borrowed Object. It is reported by Pool.Leaks() and Hooks.OnLeak.
+	//Stack trace capturing is expensive so this mode is intended for debugging.
+	TrackBorrowers bool
+
+	//Duration after which borrowed and not released Object is reported by Hooks.OnLeak.
+	//Can be 0 - in this case leaks are not reported.
+	LeakThreshold time.Duration
+
+	//Max duration Object can be borrowed for. When it is exceeded Object is considered abandoned and is destroyed,
+	//then Pool.Release() of the Object returns ErrAbandoned. Abandoned Objects are remembered for 10 * MaxBorrowDuration,
+	//Pool.Release() of the Object returns nil after that. Can be 0 - in this case borrow duration is not limited.
+	MaxBorrowDuration time.Duration
+
+	//Decides whether borrowed Object which has failed with an error is returned to pool, destroyed or quarantined (see Pool.Do() and Pool.ReleaseWithError()).
+	//Can be nil - in this case Object is destroyed on any error.
+	ErrorClassifier ErrorClassifier
+
+	//Duration during which quarantined Object is kept out of pool (see ActionQuarantine).
+	//Quarantined Object is validated (see Validator) before it is returned to pool. If it is 0 then quarantined Objects are destroyed.
+	QuarantineDuration time.Duration
+
+	//Number of consecutive errors reported by Pool.ReleaseWithError() after which Object is destroyed whatever ErrorClassifier decides.
+	//0 means no limit.
+	MaxConsecutiveErrors int
+
+	//Callbacks of pool Object lifecycle transitions.
+	Hooks Hooks
+
 	//Factory of pool Objects.
+	//It is used by NewPool only, NewTypedPool takes a TypedCreator instead.
 	Factory Creator
 }
 
 func (c Config) validate() error {
 
-	if c.Capacity < 1 {
+	if err := validateCapacity(c.Capacity, c.MinCapacity); err != nil {
+		return err
+	}
+
+	if c.ItemLifetimeCheckPeriod == 0 && c.isMaintained() {
+		return errors.New("please specify ItemLifetimeCheckPeriod")
+	}
+
+	if err := c.IdleStrategy.validate(); err != nil {
+		return err
+	}
+
+	if err := c.Backoff.validate(); err != nil {
+		return err
+	}
+
+	if err := c.CircuitBreaker.validate(); err != nil {
+		return err
+	}
+
+	if c.MaxBorrowDuration < 0 {
+		return errors.New("max borrow duration value must not be negative")
+	}
+
+	if c.LeakThreshold < 0 {
+		return errors.New("leak threshold value must not be negative")
+	}
+
+	if c.MaxIdleTime < 0 {
+		return errors.New("max idle time value must not be negative")
+	}
+
+	if c.MaxLifetime < 0 {
+		return errors.New("max lifetime value must not be negative")
+	}
+
+	if c.RefreshAhead < 0 {
+		return errors.New("refresh ahead value must not be negative")
+	}
+
+	if c.RefreshAhead > 0 && c.MaxLifetime == 0 {
+		return errors.New("please specify MaxLifetime for RefreshAhead")
+	}
+
+	if c.RefreshAhead > 0 && c.RefreshAhead >= c.MaxLifetime {
+		return errors.New("refresh ahead value must be less than max lifetime")
+	}
+
+	if c.InvalidateConcurrency < 0 {
+		return errors.New("invalidate concurrency value must not be negative")
+	}
+
+	if c.LifetimeJitter < 0 || c.LifetimeJitter > 1 {
+		return errors.New("lifetime jitter value must be from 0 to 1")
+	}
+
+	if c.MaxExpirationsPerCheck < 0 {
+		return errors.New("max expirations per check value must not be negative")
+	}
+
+	if c.QuarantineDuration < 0 {
+		return errors.New("quarantine duration value must not be negative")
+	}
+
+	if c.MaxConsecutiveErrors < 0 {
+		return errors.New("max consecutive errors value must not be negative")
+	}
+
+	if c.IdleTestsPerCheck < 0 {
+		return errors.New("idle tests per check value must not be negative")
+	}
+
+	return nil
+}
+
+//isMaintained returns true if pool needs periodic maintenance (see ItemLifetimeCheckPeriod)
+func (c Config) isMaintained() bool {
+	return c.maxIdleTime() > 0 || c.MaxLifetime > 0 || c.TestWhileIdle || c.LeakThreshold > 0 || c.MaxBorrowDuration > 0 || c.QuarantineDuration > 0
+}
+
+//maxIdleTime returns MaxIdleTime or deprecated ItemLifetime if MaxIdleTime is not specified
+func (c Config) maxIdleTime() time.Duration {
+	if c.MaxIdleTime > 0 {
+		return c.MaxIdleTime
+	}
+	return c.ItemLifetime
+}
+
+func validateCapacity(capacity int, minCapacity int) error {
+
+	if capacity < 1 {
 		return errors.New("pool capacity value must be more than 0")
 	}
 
-	if c.MinCapacity < 0 {
+	if minCapacity < 0 {
 		return errors.New("min pool capacity value must not be negative")
 	}
 
-	if c.Capacity < c.MinCapacity {
+	if capacity < minCapacity {
 		return errors.New("pool capacity value cannot be less than init capacity value")
 	}
 
-	if c.ItemLifetimeCheckPeriod == 0 && c.ItemLifetime > 0 {
-		return errors.New("please specify ItemLifetimeCheckPeriod")
-	}
-
 	return nil
 }
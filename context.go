@@ -0,0 +1,16 @@
+package ggpool
+
+import "context"
+
+//valueContext is cancelled together with the pool context but also carries values of the caller context
+type valueContext struct {
+	context.Context
+	values context.Context
+}
+
+func (c valueContext) Value(key interface{}) interface{} {
+	if value := c.values.Value(key); value != nil {
+		return value
+	}
+	return c.Context.Value(key)
+}
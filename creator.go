@@ -7,3 +7,11 @@ type Creator interface {
 	//Type of object which Create returns must be "pointer"
 	Create(ctx context.Context) (interface{}, error)
 }
+
+//TypedCreator is interface which factory of TypedPool must implement
+type TypedCreator[T comparable] interface {
+	//T must be a pointer type which implements Object interface.
+	//Pool identifies Objects by T values, so each created value must be distinct. E.g. pointers to zero-size structs may be equal,
+	//so they must not be used. Object which is equal to another pool Object is destroyed and ErrInvalidObject is returned
+	Create(ctx context.Context) (T, error)
+}
@@ -0,0 +1,129 @@
+package ggpool
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type timeoutError string
+
+func (e timeoutError) Error() string {
+	return string(e)
+}
+
+//TimeoutError is type of temporary error. It is kept for compatibility, use ErrTimeout
+const TimeoutError = timeoutError("timeout exceeded - cannot get pool item")
+
+var (
+	//ErrClosed is returned when pool is closed or is being shut down
+	ErrClosed = errors.New("pool is closed")
+
+	//ErrPoolClosed is kept for compatibility, use ErrClosed
+	ErrPoolClosed = ErrClosed
+
+	//ErrTimeout is returned by Pool.Get() when Config.Timeout is exceeded
+	ErrTimeout error = TimeoutError
+
+	//ErrInvalidObject is returned when factory creates an object which cannot be pooled
+	ErrInvalidObject = errors.New("invalid pool object")
+
+	//ErrExhausted is returned when there is no capacity for a new object
+	ErrExhausted = errors.New("pool is exhausted")
+
+	//ErrCircuitOpen is returned when object creation is suspended by circuit breaker (see Config.CircuitBreaker)
+	ErrCircuitOpen = errors.New("circuit breaker is open - object creation is suspended")
+)
+
+//ErrAbandoned is returned by Pool.Release() when Object has been destroyed because Config.MaxBorrowDuration is exceeded
+var ErrAbandoned = errors.New("object is abandoned - max borrow duration is exceeded")
+
+//FactoryError is returned when factory fails to create an Object
+type FactoryError struct {
+	//Factory error
+	Err error
+	//Number of consecutive failed factory calls including this one
+	Attempts int
+	//Time when factory was called
+	StartTime time.Time
+	//Duration of factory call
+	Duration time.Duration
+}
+
+func (e *FactoryError) Error() string {
+	return fmt.Sprintf("cannot create pool object (attempt %d, %s): %s", e.Attempts, e.Duration, e.Err)
+}
+
+func (e *FactoryError) Unwrap() error {
+	return e.Err
+}
+
+//ShutdownError is returned by Pool.Shutdown() when borrowed Objects are not released before the context is done.
+//Such Objects are destroyed by force
+type ShutdownError struct {
+	//Context error
+	Err error
+	//Objects which were not released
+	Unreleased []Object
+}
+
+func (e *ShutdownError) Error() string {
+	return fmt.Sprintf("pool is shut down with %d unreleased objects: %s", len(e.Unreleased), e.Err)
+}
+
+func (e *ShutdownError) Unwrap() error {
+	return e.Err
+}
+
+//ReadyError is returned by Pool.WaitReady() when MinCapacity Objects cannot be created
+type ReadyError struct {
+	//Context error. It is nil if failed creations are not retried (see Config.Backoff)
+	Err error
+	//Errors of failed Object creations
+	Errors []error
+}
+
+func (e *ReadyError) Error() string {
+	var messages []string
+	for _, err := range e.Errors {
+		messages = append(messages, err.Error())
+	}
+
+	message := fmt.Sprintf("pool is not ready - %d object creations failed", len(e.Errors))
+
+	if e.Err != nil {
+		message += ": " + e.Err.Error()
+	}
+	if len(messages) > 0 {
+		message += ": " + strings.Join(messages, "; ")
+	}
+	return message
+}
+
+//Is reports whether context error or any of creation errors matches target
+func (e *ReadyError) Is(target error) bool {
+	for _, err := range e.causes() {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+//As finds the first of context error and creation errors which matches target
+func (e *ReadyError) As(target interface{}) bool {
+	for _, err := range e.causes() {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *ReadyError) causes() []error {
+	if e.Err != nil {
+		return append([]error{e.Err}, e.Errors...)
+	}
+	return e.Errors
+}
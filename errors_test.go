@@ -0,0 +1,121 @@
+package ggpool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zav0x/ggpool"
+)
+
+var errBackendDown = errors.New("backend is down")
+
+type FailingFactory struct{}
+
+func (f *FailingFactory) Create(ctx context.Context) (interface{}, error) {
+	//the delay makes factory call duration measurable
+	time.Sleep(2 * time.Millisecond)
+	return nil, errBackendDown
+}
+
+type ValueFactory struct{}
+
+func (f *ValueFactory) Create(ctx context.Context) (interface{}, error) {
+	return MockConnection{}, nil
+}
+
+func TestErrors(t *testing.T) {
+
+	testCases := []struct {
+		description string
+		factory     ggpool.Creator
+		expected    error
+	}{
+		{"TestErrors, case 1: Factory error", &FailingFactory{}, errBackendDown},
+		{"TestErrors, case 2: Invalid object", &ValueFactory{}, ggpool.ErrInvalidObject},
+	}
+
+	for _, testCase := range testCases {
+		pool, err := ggpool.NewPool(context.Background(), ggpool.Config{
+			Capacity:    1,
+			MinCapacity: 0,
+			Timeout:     time.Second,
+			Factory:     testCase.factory,
+		})
+
+		if err != nil {
+			t.Fatalf("%s: Unexpected NewPool() method error: %s", testCase.description, err)
+		}
+
+		_, err = pool.Get()
+
+		if !errors.Is(err, testCase.expected) {
+			t.Fatalf("%s: Unexpected Get() method error: %v", testCase.description, err)
+		}
+
+		pool.Close()
+	}
+}
+
+func TestFactoryError(t *testing.T) {
+
+	pool, err := ggpool.NewPool(context.Background(), ggpool.Config{
+		Capacity:    1,
+		MinCapacity: 0,
+		Timeout:     time.Second,
+		Factory:     &FailingFactory{},
+	})
+
+	if err != nil {
+		t.Fatalf("TestFactoryError: Unexpected NewPool() method error: %s", err)
+	}
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		_, err = pool.Get()
+
+		var factoryErr *ggpool.FactoryError
+		if !errors.As(err, &factoryErr) {
+			t.Fatalf("TestFactoryError: Unexpected Get() method error: %v", err)
+		}
+
+		assertEqual(t, attempt, factoryErr.Attempts, "TestFactoryError: Unexpected attempts count")
+
+		if factoryErr.Duration < 2*time.Millisecond {
+			t.Fatalf("TestFactoryError: Unexpected factory call duration: %s", factoryErr.Duration)
+		}
+	}
+
+	pool.Close()
+
+	if _, err := pool.Get(); !errors.Is(err, ggpool.ErrClosed) {
+		t.Fatalf("TestFactoryError: Unexpected Get() method error: %v", err)
+	}
+}
+
+func TestTimeoutError(t *testing.T) {
+
+	pool, err := ggpool.NewPool(context.Background(), ggpool.Config{
+		Capacity:    1,
+		MinCapacity: 1,
+		Timeout:     time.Millisecond,
+		Factory:     &MockFactory{},
+	})
+
+	if err != nil {
+		t.Fatalf("TestTimeoutError: Unexpected NewPool() method error: %s", err)
+	}
+
+	object, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestTimeoutError: Unexpected Get() method error: %s", err)
+	}
+
+	if _, err := pool.Get(); !errors.Is(err, ggpool.ErrTimeout) {
+		t.Fatalf("TestTimeoutError: Unexpected Get() method error: %v", err)
+	}
+
+	pool.Release(object)
+	pool.Close()
+}
@@ -9,10 +9,13 @@ import (
 )
 
 // TODO to create real connection?
-type Connection struct{}
+type Connection struct {
+	//pool objects must be distinct, so Connection is not a zero-size struct
+	isClosed bool
+}
 
 func (c *Connection) Destroy() {
-	return
+	c.isClosed = true
 }
 
 func (c *Connection) RunCommand() {
@@ -31,6 +34,12 @@ func CreateConnection() (*Connection, error) {
 	return c, nil
 }
 
+type TypedFactory struct{}
+
+func (f *TypedFactory) Create(ctx context.Context) (*Connection, error) {
+	return CreateConnection()
+}
+
 func Example() {
 
 	factory := &Factory{}
@@ -64,3 +73,33 @@ func Example() {
 	pool.Release(obj)
 	pool.Close()
 }
+
+func ExampleTypedPool() {
+
+	config := ggpool.Config{
+		Capacity:                5,
+		MinCapacity:             3,
+		ItemLifetime:            20 * time.Second,
+		ItemLifetimeCheckPeriod: 3 * time.Second,
+		Timeout:                 3 * time.Second,
+	}
+
+	pool, err := ggpool.NewTypedPool[*Connection](context.Background(), config, &TypedFactory{})
+
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	connection, err := pool.Get()
+
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	connection.RunCommand()
+
+	pool.Release(connection)
+	pool.Close()
+}
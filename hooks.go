@@ -0,0 +1,125 @@
+package ggpool
+
+import "log"
+
+//DestroyReason describes why Object is destroyed
+type DestroyReason int
+
+const (
+	//DestroyExplicit means that Object is destroyed by Pool.Destroy()
+	DestroyExplicit DestroyReason = iota
+	//DestroyExpired means that Object lifetime is exceeded
+	DestroyExpired
+	//DestroyValidationFailed means that Object validation failed (see Validator)
+	DestroyValidationFailed
+	//DestroyPoolClosing means that Object is destroyed because pool is closed
+	DestroyPoolClosing
+	//DestroyEvicted means that idle Object is destroyed to make room for Objects of another key (see KeyedPool)
+	DestroyEvicted
+	//DestroyResized means that Object is destroyed because pool capacity is reduced (see Pool.Resize)
+	DestroyResized
+	//DestroyAbandoned means that borrowed Object is destroyed because Config.MaxBorrowDuration is exceeded
+	DestroyAbandoned
+	//DestroyFailed means that borrowed Object is destroyed because it has failed (see Config.ErrorClassifier)
+	DestroyFailed
+	//DestroyActivationFailed means that Object activation on Get failed (see Activator)
+	DestroyActivationFailed
+	//DestroyPassivationFailed means that Object passivation on Release failed (see Passivator)
+	DestroyPassivationFailed
+	//DestroyRefreshed means that idle Object is replaced by a new one before its lifetime expires (see Config.RefreshAhead)
+	DestroyRefreshed
+	//DestroyInvalidated means that Object is destroyed because pool has been invalidated (see Pool.Invalidate)
+	DestroyInvalidated
+)
+
+func (r DestroyReason) String() string {
+	switch r {
+	case DestroyExplicit:
+		return "explicit"
+	case DestroyExpired:
+		return "expired"
+	case DestroyValidationFailed:
+		return "validation failed"
+	case DestroyPoolClosing:
+		return "pool closing"
+	case DestroyEvicted:
+		return "evicted"
+	case DestroyResized:
+		return "resized"
+	case DestroyAbandoned:
+		return "abandoned"
+	case DestroyFailed:
+		return "failed"
+	case DestroyActivationFailed:
+		return "activation failed"
+	case DestroyPassivationFailed:
+		return "passivation failed"
+	case DestroyRefreshed:
+		return "refreshed"
+	case DestroyInvalidated:
+		return "invalidated"
+	}
+	return "unknown"
+}
+
+//Hooks are callbacks of pool Object lifecycle transitions (see Config.Hooks).
+//Any of them can be nil. Hooks are called outside pool internal locks, so they may use the pool
+type Hooks struct {
+	//OnCreate is called when a new Object is added to pool
+	OnCreate func(object Object)
+
+	//OnBorrow is called when Object is handed out by Get
+	OnBorrow func(object Object)
+
+	//OnRelease is called when Object is returned to pool by Release
+	OnRelease func(object Object)
+
+	//OnDestroy is called when Object is destroyed
+	OnDestroy func(object Object, reason DestroyReason)
+
+	//OnExpire is called when Object lifetime is exceeded, before Object is destroyed
+	OnExpire func(object Object)
+
+	//OnLeak is called once per borrowing when Object is borrowed for more than Config.LeakThreshold.
+	//If it is nil then the leak is logged by standard logger
+	OnLeak func(leak Leak)
+}
+
+func (h Hooks) onCreate(object Object) {
+	if h.OnCreate != nil {
+		h.OnCreate(object)
+	}
+}
+
+func (h Hooks) onBorrow(object Object) {
+	if h.OnBorrow != nil {
+		h.OnBorrow(object)
+	}
+}
+
+func (h Hooks) onRelease(object Object) {
+	if h.OnRelease != nil {
+		h.OnRelease(object)
+	}
+}
+
+func (h Hooks) onDestroy(object Object, reason DestroyReason) {
+	if h.OnDestroy != nil {
+		h.OnDestroy(object, reason)
+	}
+}
+
+func (h Hooks) onExpire(object Object) {
+	if h.OnExpire != nil {
+		h.OnExpire(object)
+	}
+}
+
+func (h Hooks) onLeak(leak Leak) {
+	if h.OnLeak != nil {
+		h.OnLeak(leak)
+		return
+	}
+
+	log.Printf("ggpool: object %T is borrowed for %s and not released\n%s", leak.Object, leak.Duration, leak.Stack)
+}
@@ -0,0 +1,57 @@
+package ggpool
+
+import (
+	"container/list"
+	"errors"
+	"math/rand"
+)
+
+//IdleStrategy defines which idle Object is handed out by Get (see Config.IdleStrategy)
+type IdleStrategy int
+
+const (
+	//IdleLIFO hands out the most recently released Object. Hot Objects are reused and the rest expire by MaxIdleTime
+	IdleLIFO IdleStrategy = iota
+	//IdleFIFO hands out the least recently released Object. Load is spread evenly over all pool Objects
+	IdleFIFO
+	//IdleRandom hands out a random idle Object
+	IdleRandom
+)
+
+func (s IdleStrategy) String() string {
+	switch s {
+	case IdleLIFO:
+		return "lifo"
+	case IdleFIFO:
+		return "fifo"
+	case IdleRandom:
+		return "random"
+	}
+	return "unknown"
+}
+
+func (s IdleStrategy) validate() error {
+	if s < IdleLIFO || s > IdleRandom {
+		return errors.New("unknown idle strategy")
+	}
+	return nil
+}
+
+//pick returns element of idle list which is ordered by release time, the most recently released element is at the back
+func (s IdleStrategy) pick(idle *list.List) *list.Element {
+	switch s {
+	case IdleFIFO:
+		return idle.Front()
+	case IdleRandom:
+		if idle.Len() == 0 {
+			return nil
+		}
+
+		element := idle.Front()
+		for i := rand.Intn(idle.Len()); i > 0; i-- {
+			element = element.Next()
+		}
+		return element
+	}
+	return idle.Back()
+}
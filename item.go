@@ -1,36 +1,153 @@
 package ggpool
 
 import (
+	"sync"
 	"time"
 )
 
-type item struct {
-	object       *interface{}
-	lifetime     time.Duration
+type item[T comparable] struct {
+	value        T
+	object       Object
+	maxIdleTime  time.Duration
+	maxLifetime  time.Duration
+	createdTime  time.Time
 	releasedTime time.Time
+	//pool generation at the moment item creation started (see Pool.Invalidate)
+	generation int64
+	//duration before max lifetime expiration when idle item is refreshed (see Config.RefreshAhead)
+	refreshAhead time.Duration
+
+	//borrow state can be read by leak detection while item is borrowed
+	sync.Mutex
+	borrowedTime   time.Time
+	borrowerStack  string
+	isLeakReported bool
+
+	//number of consecutive errors reported by Pool.ReleaseWithError
+	errorCount int
 }
 
-func newItem(object *interface{}, lifetime time.Duration) *item {
-	return &item{
+func newItem[T comparable](value T, object Object, maxIdleTime time.Duration, maxLifetime time.Duration) *item[T] {
+	now := time.Now().UTC()
+
+	return &item[T]{
+		value:        value,
 		object:       object,
-		lifetime:     lifetime,
-		releasedTime: time.Now().UTC(),
+		maxIdleTime:  maxIdleTime,
+		maxLifetime:  maxLifetime,
+		createdTime:  now,
+		releasedTime: now,
 	}
 }
 
-func (i *item) release() {
+func (i *item[T]) borrow(stack string) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.borrowedTime = time.Now().UTC()
+	i.borrowerStack = stack
+	i.isLeakReported = false
+}
+
+func (i *item[T]) borrowDuration() time.Duration {
+	i.Lock()
+	defer i.Unlock()
+
+	return time.Now().UTC().Sub(i.borrowedTime)
+}
+
+//leak returns item Leak if item is borrowed for more than threshold
+func (i *item[T]) leak(threshold time.Duration) (Leak, bool) {
+	i.Lock()
+	defer i.Unlock()
+
+	duration := time.Now().UTC().Sub(i.borrowedTime)
+
+	if i.borrowedTime.IsZero() || duration < threshold {
+		return Leak{}, false
+	}
+
+	return Leak{
+		Object:       i.object,
+		BorrowedTime: i.borrowedTime,
+		Duration:     duration,
+		Stack:        i.borrowerStack,
+	}, true
+}
+
+//reportLeak marks item leak as reported. It returns false if the leak has been already reported
+func (i *item[T]) reportLeak() bool {
+	i.Lock()
+	defer i.Unlock()
+
+	isReported := i.isLeakReported
+	i.isLeakReported = true
+
+	return !isReported
+}
+
+//failure counts item error. It returns the number of consecutive errors
+func (i *item[T]) failure() int {
+	i.Lock()
+	defer i.Unlock()
+
+	i.errorCount++
+
+	return i.errorCount
+}
+
+//success resets item error history
+func (i *item[T]) success() {
+	i.Lock()
+	defer i.Unlock()
+
+	i.errorCount = 0
+}
+
+func (i *item[T]) release() {
 	i.releasedTime = time.Now().UTC()
+
+	i.Lock()
+	defer i.Unlock()
+
+	i.borrowedTime = time.Time{}
+	i.borrowerStack = ""
 }
 
-func (i *item) destroy() {
-	(*i.object).(Object).Destroy()
+func (i *item[T]) destroy() {
+	i.object.Destroy()
 }
 
-func (i *item) isActive() bool {
-	if i.lifetime == 0 {
+//isActive returns false if idle item has exceeded either max idle time or max lifetime
+func (i *item[T]) isActive() bool {
+	if i.isAged() {
+		return false
+	}
+
+	if i.maxIdleTime == 0 {
 		return true
 	}
 
-	expireTime := i.releasedTime.Add(i.lifetime)
+	expireTime := i.releasedTime.Add(i.maxIdleTime)
 	return time.Now().UTC().Before(expireTime)
 }
+
+//isAging returns true if item max lifetime expires within refresh window
+func (i *item[T]) isAging() bool {
+	if i.maxLifetime == 0 || i.refreshAhead == 0 {
+		return false
+	}
+
+	refreshTime := i.createdTime.Add(i.maxLifetime - i.refreshAhead)
+	return !time.Now().UTC().Before(refreshTime)
+}
+
+//isAged returns true if item has exceeded max lifetime
+func (i *item[T]) isAged() bool {
+	if i.maxLifetime == 0 {
+		return false
+	}
+
+	expireTime := i.createdTime.Add(i.maxLifetime)
+	return !time.Now().UTC().Before(expireTime)
+}
@@ -0,0 +1,317 @@
+package ggpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+//KeyedCreator is interface which factory of KeyedPool must implement
+type KeyedCreator[K comparable, T comparable] interface {
+	//T must be a pointer type which implements Object interface
+	Create(ctx context.Context, key K) (T, error)
+}
+
+//KeyedConfig is a keyed pool configuration
+type KeyedConfig struct {
+	//Configuration of per key pools. Capacity and MinCapacity are applied to every key.
+	//Per key pools are named "<Name>/<key>".
+	Config
+
+	//Max number of Objects of all keys. Can be 0 - in this case number of Objects is limited by per key Capacity only.
+	//When the limit is reached idle Objects of the least recently used keys are destroyed to make room for the requested key.
+	TotalCapacity int
+}
+
+func (c KeyedConfig) validate() error {
+	if err := c.Config.validate(); err != nil {
+		return err
+	}
+
+	if c.TotalCapacity < 0 {
+		return errors.New("total capacity value must not be negative")
+	}
+
+	if c.TotalCapacity > 0 && c.TotalCapacity < c.MinCapacity {
+		return errors.New("total capacity value cannot be less than min capacity value")
+	}
+
+	return nil
+}
+
+//KeyedPool is a set of pools of objects of type T, one pool per key.
+//Per key pools are created lazily on first Get
+type KeyedPool[K comparable, T comparable] struct {
+	config  KeyedConfig
+	factory KeyedCreator[K, T]
+	ctx     context.Context
+	cancel  context.CancelFunc
+
+	sync.Mutex
+	pools    map[K]*keyedEntry[K, T]
+	size     int
+	isClosed bool
+}
+
+type keyedEntry[K comparable, T comparable] struct {
+	pool     *TypedPool[T]
+	lastUsed time.Time
+	//ready is closed when pool is started, err is set if the start has failed
+	ready chan struct{}
+	err   error
+}
+
+//NewKeyedPool returns a new KeyedPool instance. Config.Factory is ignored, objects are created by factory
+func NewKeyedPool[K comparable, T comparable](ctx context.Context, config KeyedConfig, factory KeyedCreator[K, T]) (*KeyedPool[K, T], error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	kp := &KeyedPool[K, T]{
+		config:  config,
+		factory: factory,
+		ctx:     ctx,
+		cancel:  cancel,
+		pools:   make(map[K]*keyedEntry[K, T]),
+	}
+
+	if err := config.validate(); err != nil {
+		kp.Close()
+		return kp, err
+	}
+
+	return kp, nil
+}
+
+//Get returns Object of key or error of Object getting/creation. See TypedPool.GetContext
+func (kp *KeyedPool[K, T]) Get(ctx context.Context, key K) (T, error) {
+	pool, err := kp.pool(ctx, key)
+
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return pool.GetContext(ctx)
+}
+
+//Release puts Object of key back to pool. See TypedPool.Release
+func (kp *KeyedPool[K, T]) Release(key K, object T) error {
+	if pool := kp.lookup(key); pool != nil {
+		return pool.Release(object)
+	}
+	return nil
+}
+
+//ReleaseWithError puts Object of key back to pool or destroys it depending on err. See TypedPool.ReleaseWithError
+func (kp *KeyedPool[K, T]) ReleaseWithError(key K, object T, err error) error {
+	if pool := kp.lookup(key); pool != nil {
+		return pool.ReleaseWithError(object, err)
+	}
+	return nil
+}
+
+//Destroy removes and destroys Object of key
+func (kp *KeyedPool[K, T]) Destroy(key K, object T) {
+	if pool := kp.lookup(key); pool != nil {
+		pool.Destroy(object)
+	}
+}
+
+//Invalidate marks all Objects of key as stale. See TypedPool.Invalidate
+func (kp *KeyedPool[K, T]) Invalidate(key K) {
+	if pool := kp.lookup(key); pool != nil {
+		pool.Invalidate()
+	}
+}
+
+//Len returns number of Objects of all keys
+func (kp *KeyedPool[K, T]) Len() int {
+	kp.Lock()
+	defer kp.Unlock()
+
+	total := 0
+	for _, entry := range kp.pools {
+		total += entry.pool.Len()
+	}
+	return total
+}
+
+//KeyLen returns number of Objects of key
+func (kp *KeyedPool[K, T]) KeyLen(key K) int {
+	if pool := kp.lookup(key); pool != nil {
+		return pool.Len()
+	}
+	return 0
+}
+
+//Close clears and closes pools of all keys
+func (kp *KeyedPool[K, T]) Close() error {
+	kp.Lock()
+
+	var pools []*TypedPool[T]
+	for _, entry := range kp.pools {
+		if entry.pool.itemCollection.lenBorrowed() > 0 {
+			kp.Unlock()
+			return errors.New("pool cannot be closed - there are unreleased items")
+		}
+		pools = append(pools, entry.pool)
+	}
+
+	kp.isClosed = true
+	kp.cancel()
+	kp.Unlock()
+
+	//pools are closed outside the lock because destroyed items release total capacity
+	for _, pool := range pools {
+		pool.Close()
+	}
+
+	return nil
+}
+
+//pool returns pool of key, the pool is created if it doesn't exist. It waits until the pool is started or ctx is done
+func (kp *KeyedPool[K, T]) pool(ctx context.Context, key K) (*TypedPool[T], error) {
+	kp.Lock()
+
+	if kp.isClosed {
+		kp.Unlock()
+		return nil, ErrClosed
+	}
+
+	entry, ok := kp.pools[key]
+
+	if ok {
+		entry.lastUsed = time.Now().UTC()
+		kp.Unlock()
+
+		select {
+		case <-entry.ready:
+			if entry.err != nil {
+				return nil, entry.err
+			}
+			return entry.pool, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	config := kp.config.Config
+	config.Name = fmt.Sprintf("%s/%v", config.Name, key)
+
+	entry = &keyedEntry[K, T]{lastUsed: time.Now().UTC(), ready: make(chan struct{})}
+	entry.pool = newTypedPool[T](kp.ctx, config, keyedCreatorAdapter[K, T]{factory: kp.factory, key: key}, func(value T) interface{} {
+		return value
+	})
+
+	if kp.config.TotalCapacity > 0 {
+		entry.pool.limiter = &keyedLimiter[K, T]{pool: kp, entry: entry}
+	}
+
+	//the entry is reserved, so the pool is started outside the lock because prefilled items acquire total capacity
+	kp.pools[key] = entry
+	kp.Unlock()
+
+	if err := entry.pool.start(); err != nil {
+		kp.Lock()
+		delete(kp.pools, key)
+		kp.Unlock()
+
+		entry.err = err
+	}
+
+	close(entry.ready)
+
+	if entry.err != nil {
+		return nil, entry.err
+	}
+	return entry.pool, nil
+}
+
+//lookup returns pool of key or nil if it doesn't exist
+func (kp *KeyedPool[K, T]) lookup(key K) *TypedPool[T] {
+	kp.Lock()
+	defer kp.Unlock()
+
+	if entry, ok := kp.pools[key]; ok {
+		return entry.pool
+	}
+	return nil
+}
+
+//evict destroys an idle Object of the least recently used key except the exclude one
+func (kp *KeyedPool[K, T]) evict(exclude *keyedEntry[K, T]) bool {
+	type candidate struct {
+		pool     *TypedPool[T]
+		lastUsed time.Time
+	}
+
+	kp.Lock()
+
+	var candidates []candidate
+	for _, entry := range kp.pools {
+		if entry != exclude {
+			candidates = append(candidates, candidate{pool: entry.pool, lastUsed: entry.lastUsed})
+		}
+	}
+	kp.Unlock()
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].lastUsed.Before(candidates[j].lastUsed)
+	})
+
+	for _, candidate := range candidates {
+		if candidate.pool.evictIdle() {
+			return true
+		}
+	}
+	return false
+}
+
+//capacityLimiter limits number of items which are shared by several pools
+type capacityLimiter interface {
+	//acquire reserves capacity for a new item. It returns false if there is no capacity
+	acquire() bool
+	//release frees capacity of destroyed item
+	release()
+}
+
+//keyedLimiter limits number of items of all KeyedPool keys by KeyedConfig.TotalCapacity
+type keyedLimiter[K comparable, T comparable] struct {
+	pool  *KeyedPool[K, T]
+	entry *keyedEntry[K, T]
+}
+
+func (l *keyedLimiter[K, T]) acquire() bool {
+	for {
+		l.pool.Lock()
+		if l.pool.size < l.pool.config.TotalCapacity {
+			l.pool.size++
+			l.pool.Unlock()
+			return true
+		}
+		l.pool.Unlock()
+
+		if !l.pool.evict(l.entry) {
+			return false
+		}
+	}
+}
+
+func (l *keyedLimiter[K, T]) release() {
+	l.pool.Lock()
+	defer l.pool.Unlock()
+
+	l.pool.size--
+}
+
+//keyedCreatorAdapter creates objects of a key for TypedPool
+type keyedCreatorAdapter[K comparable, T comparable] struct {
+	factory KeyedCreator[K, T]
+	key     K
+}
+
+func (c keyedCreatorAdapter[K, T]) Create(ctx context.Context) (T, error) {
+	return c.factory.Create(ctx, c.key)
+}
@@ -0,0 +1,231 @@
+package ggpool_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zav0x/ggpool"
+)
+
+type ShardConnection struct {
+	MockConnection
+	shard string
+}
+
+type ShardFactory struct {
+	MockFactory
+}
+
+func (f *ShardFactory) Create(ctx context.Context, shard string) (*ShardConnection, error) {
+	c, err := CreateMockConnection(&f.MockFactory)
+	return &ShardConnection{MockConnection: *c, shard: shard}, err
+}
+
+func TestKeyedPool(t *testing.T) {
+
+	factory := &ShardFactory{}
+
+	pool, err := ggpool.NewKeyedPool[string, *ShardConnection](context.Background(), ggpool.KeyedConfig{
+		Config: ggpool.Config{
+			Capacity:    2,
+			MinCapacity: 0,
+			Timeout:     time.Second,
+		},
+		TotalCapacity: 2,
+	}, factory)
+
+	if err != nil {
+		t.Fatalf("TestKeyedPool: Unexpected NewKeyedPool() method error: %s", err)
+	}
+
+	for _, shard := range []string{"a", "b"} {
+		connection, err := pool.Get(context.Background(), shard)
+
+		if err != nil {
+			t.Fatalf("TestKeyedPool: Unexpected Get() method error: %s", err)
+		}
+
+		assertEqual(t, shard, connection.shard, "TestKeyedPool: Unexpected object key")
+
+		pool.Release(shard, connection)
+	}
+
+	assertEqual(t, 2, pool.Len(), "TestKeyedPool: Unexpected pool length")
+
+	//total capacity is reached, idle object of the coldest key "a" must be evicted
+	connection, err := pool.Get(context.Background(), "c")
+
+	if err != nil {
+		t.Fatalf("TestKeyedPool: Unexpected Get() method error: %s", err)
+	}
+
+	assertEqual(t, "c", connection.shard, "TestKeyedPool: Unexpected object key")
+	assertEqual(t, 2, pool.Len(), "TestKeyedPool: Unexpected pool length")
+	assertEqual(t, 0, pool.KeyLen("a"), "TestKeyedPool: Unexpected length of evicted key")
+	assertEqual(t, 1, pool.KeyLen("b"), "TestKeyedPool: Unexpected length of key")
+	assertEqual(t, 1, factory.GetDestroyedCount(), "TestKeyedPool: Unexpected destroyed items count")
+
+	pool.Release("c", connection)
+
+	if err := pool.Close(); err != nil {
+		t.Fatalf("TestKeyedPool: Unexpected Close() method error: %s", err)
+	}
+
+	assertEqual(t, 3, factory.GetDestroyedCount(), "TestKeyedPool: Unexpected destroyed items count")
+}
+
+func TestKeyedPoolConcurrentKeys(t *testing.T) {
+
+	factory := &ShardFactory{}
+
+	pool, err := ggpool.NewKeyedPool[string, *ShardConnection](context.Background(), ggpool.KeyedConfig{
+		Config: ggpool.Config{
+			Capacity:    2,
+			MinCapacity: 0,
+			Timeout:     time.Second,
+		},
+		TotalCapacity: 3,
+	}, factory)
+
+	if err != nil {
+		t.Fatalf("TestKeyedPoolConcurrentKeys: Unexpected NewKeyedPool() method error: %s", err)
+	}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(shard string) {
+			defer wg.Done()
+
+			connection, err := pool.Get(context.Background(), shard)
+			if err != nil {
+				return
+			}
+			pool.Release(shard, connection)
+		}([]string{"a", "b", "c", "d"}[i%4])
+	}
+
+	wg.Wait()
+
+	if pool.Len() > 3 {
+		t.Fatalf("TestKeyedPoolConcurrentKeys: Total capacity is exceeded: %d", pool.Len())
+	}
+
+	pool.Close()
+}
+
+func TestKeyedPoolPrefillOnStart(t *testing.T) {
+
+	factory := &ShardFactory{}
+
+	pool, err := ggpool.NewKeyedPool[string, *ShardConnection](context.Background(), ggpool.KeyedConfig{
+		Config: ggpool.Config{
+			Capacity:       2,
+			MinCapacity:    2,
+			PrefillOnStart: true,
+			Timeout:        time.Second,
+		},
+		TotalCapacity: 4,
+	}, factory)
+
+	if err != nil {
+		t.Fatalf("TestKeyedPoolPrefillOnStart: Unexpected NewKeyedPool() method error: %s", err)
+	}
+
+	for _, shard := range []string{"a", "b"} {
+		start := time.Now()
+		connection, err := pool.Get(context.Background(), shard)
+
+		if err != nil {
+			t.Fatalf("TestKeyedPoolPrefillOnStart: Unexpected Get() method error: %s", err)
+		}
+
+		assertEqual(t, true, time.Since(start) < 500*time.Millisecond, "TestKeyedPoolPrefillOnStart: Key pool is expected to start without waiting for Timeout")
+		assertEqual(t, 2, pool.KeyLen(shard), "TestKeyedPoolPrefillOnStart: Key pool is expected to be prefilled")
+
+		pool.Release(shard, connection)
+	}
+
+	assertEqual(t, 4, pool.Len(), "TestKeyedPoolPrefillOnStart: Unexpected pool length")
+
+	pool.Close()
+}
+
+func TestKeyedPoolEvictionHook(t *testing.T) {
+
+	factory := &ShardFactory{}
+	hookErrors := make(chan error, 1)
+
+	var pool *ggpool.KeyedPool[string, *ShardConnection]
+
+	pool, err := ggpool.NewKeyedPool[string, *ShardConnection](context.Background(), ggpool.KeyedConfig{
+		Config: ggpool.Config{
+			Capacity:    1,
+			MinCapacity: 0,
+			Timeout:     time.Second,
+			Hooks: ggpool.Hooks{
+				//hook creates an object of the key which has caused the eviction
+				OnDestroy: func(object ggpool.Object, reason ggpool.DestroyReason) {
+					if reason != ggpool.DestroyEvicted {
+						return
+					}
+
+					//Get call which has caused the eviction stops waiting
+					time.Sleep(20 * time.Millisecond)
+
+					ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+					defer cancel()
+
+					connection, err := pool.Get(ctx, "b")
+
+					if err == nil {
+						pool.Release("b", connection)
+					}
+
+					hookErrors <- err
+				},
+			},
+		},
+		TotalCapacity: 1,
+	}, factory)
+
+	if err != nil {
+		t.Fatalf("TestKeyedPoolEvictionHook: Unexpected NewKeyedPool() method error: %s", err)
+	}
+
+	connection, err := pool.Get(context.Background(), "a")
+
+	if err != nil {
+		t.Fatalf("TestKeyedPoolEvictionHook: Unexpected Get() method error: %s", err)
+	}
+
+	pool.Release("a", connection)
+
+	//idle object of key "a" is evicted to make room for key "b"
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+
+	pool.Get(ctx, "b")
+
+	if err := <-hookErrors; err != nil {
+		t.Fatalf("TestKeyedPoolEvictionHook: Unexpected Get() method error in hook: %s", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	connection, err = pool.Get(ctx, "b")
+
+	if err != nil {
+		t.Fatalf("TestKeyedPoolEvictionHook: Unexpected Get() method error: %s", err)
+	}
+
+	assertEqual(t, "b", connection.shard, "TestKeyedPoolEvictionHook: Unexpected object key")
+	assertEqual(t, 1, pool.Len(), "TestKeyedPoolEvictionHook: Unexpected pool length")
+
+	pool.Release("b", connection)
+	pool.Close()
+}
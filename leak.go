@@ -0,0 +1,15 @@
+package ggpool
+
+import "time"
+
+//Leak describes Object which is borrowed and not released for a long time
+type Leak struct {
+	//Borrowed Object
+	Object Object
+	//Time when Object was borrowed
+	BorrowedTime time.Time
+	//Time Object has been borrowed for
+	Duration time.Duration
+	//Stack trace of Get call which borrowed Object. It is recorded only when Config.TrackBorrowers is set
+	Stack string
+}
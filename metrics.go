@@ -0,0 +1,36 @@
+package ggpool
+
+import "time"
+
+//MetricsRecorder is interface of pool metrics receiver (see Config.Metrics).
+//Methods are called synchronously by pool operations so they must be fast and safe for concurrent use.
+//pool argument is Config.Name of the pool which reports metrics
+type MetricsRecorder interface {
+	//ObserveWait is called when Get returns with time spent on getting an Object
+	ObserveWait(pool string, duration time.Duration)
+
+	//ObserveBorrow is called when borrowed Object is released or destroyed with time the Object was borrowed for
+	ObserveBorrow(pool string, duration time.Duration)
+
+	//ObserveCreate is called when factory returns with factory call latency and its error
+	ObserveCreate(pool string, duration time.Duration, err error)
+
+	//ObserveDestroy is called when Object is destroyed
+	ObserveDestroy(pool string)
+
+	//SetSize is called when pool size is changed with current numbers of all, idle Objects and waiting Get calls
+	SetSize(pool string, total int, idle int, waiting int)
+}
+
+//noopMetrics is MetricsRecorder which is used when Config.Metrics is not specified
+type noopMetrics struct{}
+
+func (noopMetrics) ObserveWait(pool string, duration time.Duration) {}
+
+func (noopMetrics) ObserveBorrow(pool string, duration time.Duration) {}
+
+func (noopMetrics) ObserveCreate(pool string, duration time.Duration, err error) {}
+
+func (noopMetrics) ObserveDestroy(pool string) {}
+
+func (noopMetrics) SetSize(pool string, total int, idle int, waiting int) {}
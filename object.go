@@ -1,7 +1,34 @@
 package ggpool
 
+import "context"
+
 //Object is interface of pool object. Object that is created by Factory (see Config) must implement this interface
 type Object interface {
-	//Destroy is called when ItemLifetime is exceeded
+	//Destroy is called when Object is removed from pool, e.g. when MaxIdleTime or MaxLifetime is exceeded
 	Destroy()
 }
+
+//Validator is optional interface of pool object.
+//If Object implements it then Validate is called on Get when Config.TestOnBorrow is set and on Release when Config.TestOnReturn is set.
+//Object is destroyed when Validate returns error
+type Validator interface {
+	Validate(ctx context.Context) error
+}
+
+//Resetter is optional interface of pool object.
+//If Object implements it then Reset is called when Object is returned to pool to clear state left by the borrower
+type Resetter interface {
+	Reset()
+}
+
+//Activator is optional interface of pool object.
+//If Object implements it then Activate is called before Object is handed out by Get. Object is destroyed when Activate returns error
+type Activator interface {
+	Activate(ctx context.Context) error
+}
+
+//Passivator is optional interface of pool object.
+//If Object implements it then Passivate is called when Object is returned to pool. Object is destroyed when Passivate returns error
+type Passivator interface {
+	Passivate(ctx context.Context) error
+}
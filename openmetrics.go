@@ -0,0 +1,219 @@
+package ggpool
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//OpenMetricsRecorder is MetricsRecorder which exposes metrics in OpenMetrics text format.
+//It implements http.Handler and can be shared by several pools, metrics of each pool are labeled with pool name (see Config.Name)
+type OpenMetricsRecorder struct {
+	sync.Mutex
+	buckets []float64
+	pools   map[string]*poolMetrics
+}
+
+type poolMetrics struct {
+	wait           *histogram
+	borrow         *histogram
+	create         *histogram
+	createFailures uint64
+	destroys       uint64
+	total          int
+	idle           int
+	waiting        int
+}
+
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+//DefaultMetricsBuckets are upper bounds (in seconds) of OpenMetricsRecorder histogram buckets
+var DefaultMetricsBuckets = []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+//NewOpenMetricsRecorder returns a new OpenMetricsRecorder instance.
+//buckets are upper bounds (in seconds) of duration histogram buckets, DefaultMetricsBuckets are used if buckets is empty
+func NewOpenMetricsRecorder(buckets ...float64) *OpenMetricsRecorder {
+	if len(buckets) == 0 {
+		buckets = DefaultMetricsBuckets
+	}
+
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+
+	return &OpenMetricsRecorder{
+		buckets: buckets,
+		pools:   make(map[string]*poolMetrics),
+	}
+}
+
+//ObserveWait implements MetricsRecorder
+func (r *OpenMetricsRecorder) ObserveWait(pool string, duration time.Duration) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.get(pool).wait.observe(r.buckets, duration)
+}
+
+//ObserveBorrow implements MetricsRecorder
+func (r *OpenMetricsRecorder) ObserveBorrow(pool string, duration time.Duration) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.get(pool).borrow.observe(r.buckets, duration)
+}
+
+//ObserveCreate implements MetricsRecorder
+func (r *OpenMetricsRecorder) ObserveCreate(pool string, duration time.Duration, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	metrics := r.get(pool)
+	metrics.create.observe(r.buckets, duration)
+
+	if err != nil {
+		metrics.createFailures++
+	}
+}
+
+//ObserveDestroy implements MetricsRecorder
+func (r *OpenMetricsRecorder) ObserveDestroy(pool string) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.get(pool).destroys++
+}
+
+//SetSize implements MetricsRecorder
+func (r *OpenMetricsRecorder) SetSize(pool string, total int, idle int, waiting int) {
+	r.Lock()
+	defer r.Unlock()
+
+	metrics := r.get(pool)
+	metrics.total = total
+	metrics.idle = idle
+	metrics.waiting = waiting
+}
+
+//ServeHTTP writes metrics of all pools in OpenMetrics text format
+func (r *OpenMetricsRecorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/openmetrics-text; version=1.0.0; charset=utf-8")
+	w.Write(r.render())
+}
+
+func (r *OpenMetricsRecorder) get(pool string) *poolMetrics {
+	metrics, ok := r.pools[pool]
+
+	if !ok {
+		metrics = &poolMetrics{
+			wait:   newHistogram(r.buckets),
+			borrow: newHistogram(r.buckets),
+			create: newHistogram(r.buckets),
+		}
+		r.pools[pool] = metrics
+	}
+	return metrics
+}
+
+func (r *OpenMetricsRecorder) render() []byte {
+	r.Lock()
+	defer r.Unlock()
+
+	var names []string
+	for name := range r.pools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b bytes.Buffer
+
+	histograms := []struct {
+		name string
+		help string
+		get  func(*poolMetrics) *histogram
+	}{
+		{"ggpool_wait_seconds", "Time spent on getting an object from the pool.", func(m *poolMetrics) *histogram { return m.wait }},
+		{"ggpool_borrow_seconds", "Time an object was borrowed for.", func(m *poolMetrics) *histogram { return m.borrow }},
+		{"ggpool_create_seconds", "Latency of object creation.", func(m *poolMetrics) *histogram { return m.create }},
+	}
+
+	for _, h := range histograms {
+		fmt.Fprintf(&b, "# TYPE %s histogram\n# HELP %s %s\n", h.name, h.name, h.help)
+		for _, name := range names {
+			h.get(r.pools[name]).write(&b, h.name, poolLabel(name), r.buckets)
+		}
+	}
+
+	counters := []struct {
+		name string
+		help string
+		get  func(*poolMetrics) uint64
+	}{
+		{"ggpool_create_failures", "Number of failed object creations.", func(m *poolMetrics) uint64 { return m.createFailures }},
+		{"ggpool_destroyed", "Number of destroyed objects.", func(m *poolMetrics) uint64 { return m.destroys }},
+	}
+
+	for _, c := range counters {
+		fmt.Fprintf(&b, "# TYPE %s counter\n# HELP %s %s\n", c.name, c.name, c.help)
+		for _, name := range names {
+			fmt.Fprintf(&b, "%s_total{%s} %d\n", c.name, poolLabel(name), c.get(r.pools[name]))
+		}
+	}
+
+	b.WriteString("# TYPE ggpool_objects gauge\n# HELP ggpool_objects Number of objects in the pool.\n")
+	for _, name := range names {
+		metrics := r.pools[name]
+		fmt.Fprintf(&b, "ggpool_objects{%s,state=\"idle\"} %d\n", poolLabel(name), metrics.idle)
+		fmt.Fprintf(&b, "ggpool_objects{%s,state=\"in_use\"} %d\n", poolLabel(name), metrics.total-metrics.idle)
+	}
+
+	b.WriteString("# TYPE ggpool_waiting gauge\n# HELP ggpool_waiting Number of callers waiting for an object.\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "ggpool_waiting{%s} %d\n", poolLabel(name), r.pools[name].waiting)
+	}
+
+	b.WriteString("# EOF\n")
+
+	return b.Bytes()
+}
+
+func newHistogram(buckets []float64) *histogram {
+	return &histogram{
+		counts: make([]uint64, len(buckets)),
+	}
+}
+
+func (h *histogram) observe(buckets []float64, duration time.Duration) {
+	value := duration.Seconds()
+
+	for i, bound := range buckets {
+		if value <= bound {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += value
+}
+
+func (h *histogram) write(b *bytes.Buffer, name string, labels string, buckets []float64) {
+	for i, bound := range buckets {
+		fmt.Fprintf(b, "%s_bucket{%s,le=\"%s\"} %d\n", name, labels, strconv.FormatFloat(bound, 'g', -1, 64), h.counts[i])
+	}
+	fmt.Fprintf(b, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, labels, h.count)
+	fmt.Fprintf(b, "%s_sum{%s} %s\n", name, labels, strconv.FormatFloat(h.sum, 'g', -1, 64))
+	fmt.Fprintf(b, "%s_count{%s} %d\n", name, labels, h.count)
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func poolLabel(name string) string {
+	return `pool="` + labelValueReplacer.Replace(name) + `"`
+}
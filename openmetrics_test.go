@@ -0,0 +1,84 @@
+package ggpool_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zav0x/ggpool"
+)
+
+func TestOpenMetricsRecorder(t *testing.T) {
+
+	recorder := ggpool.NewOpenMetricsRecorder()
+
+	var pools []*ggpool.Pool
+
+	for _, name := range []string{"users", "orders"} {
+		pool, err := ggpool.NewPool(context.Background(), ggpool.Config{
+			Name:        name,
+			Capacity:    1,
+			MinCapacity: 0,
+			Timeout:     time.Second,
+			Metrics:     recorder,
+			Factory:     &MockFactory{},
+		})
+
+		if err != nil {
+			t.Fatalf("TestOpenMetricsRecorder: Unexpected NewPool() method error: %s", err)
+		}
+
+		pools = append(pools, pool)
+	}
+
+	object, err := pools[0].Get()
+
+	if err != nil {
+		t.Fatalf("TestOpenMetricsRecorder: Unexpected Get() method error: %s", err)
+	}
+
+	pools[0].Destroy(object)
+
+	object, err = pools[1].Get()
+
+	if err != nil {
+		t.Fatalf("TestOpenMetricsRecorder: Unexpected Get() method error: %s", err)
+	}
+
+	response := httptest.NewRecorder()
+	recorder.ServeHTTP(response, httptest.NewRequest("GET", "/metrics", nil))
+
+	body := response.Body.String()
+
+	for _, line := range []string{
+		"# TYPE ggpool_wait_seconds histogram",
+		`ggpool_wait_seconds_count{pool="users"} 1`,
+		`ggpool_wait_seconds_count{pool="orders"} 1`,
+		`ggpool_borrow_seconds_count{pool="users"} 1`,
+		`ggpool_create_seconds_count{pool="orders"} 1`,
+		`ggpool_destroyed_total{pool="users"} 1`,
+		`ggpool_destroyed_total{pool="orders"} 0`,
+		`ggpool_objects{pool="orders",state="in_use"} 1`,
+		`ggpool_waiting{pool="users"} 0`,
+		"# EOF",
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Fatalf("TestOpenMetricsRecorder: Line %q is not found in:\n%s", line, body)
+		}
+	}
+
+	assertEqual(
+		t,
+		"application/openmetrics-text; version=1.0.0; charset=utf-8",
+		response.Header().Get("Content-Type"),
+		"TestOpenMetricsRecorder: Unexpected content type",
+	)
+
+	pools[1].Release(object)
+
+	for _, pool := range pools {
+		pool.Close()
+	}
+}
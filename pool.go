@@ -3,306 +3,109 @@ package ggpool
 
 import (
 	"context"
-	"errors"
-	"reflect"
-	"sync"
 	"time"
 )
 
-type timeoutError string
+//Pool is a pool of generic objects.
+//Pool is an adapter over TypedPool which hands out objects as *interface{}, use TypedPool to get objects of a concrete type
+type Pool struct {
+	pool *TypedPool[*interface{}]
+}
 
-func (e timeoutError) Error() string {
-	return string(e)
+//creatorAdapter wraps Creator to create *interface{} values for TypedPool
+type creatorAdapter struct {
+	factory Creator
 }
 
-//TimeoutError is type of temporary error
-const TimeoutError = timeoutError("timeout exceeded - cannot get pool item")
+func (c creatorAdapter) Create(ctx context.Context) (*interface{}, error) {
+	object, err := c.factory.Create(ctx)
 
-//Pool is a pool of generic objects
-type Pool struct {
-	config                Config
-	itemReleasedCh        chan bool
-	itemDestroyedCh       chan bool
-	createItemLastErrorCh chan error
-	ctx                   context.Context
-	cancel                context.CancelFunc
-
-	sync.RWMutex
-	itemCollection *collection
-	isInitialized  bool
+	if err != nil {
+		return nil, err
+	}
+	return &object, nil
 }
 
 //NewPool returns a new Pool instanse
 func NewPool(ctx context.Context, config Config) (*Pool, error) {
-	var p *Pool
-
-	ctx, cancel := context.WithCancel(ctx)
-
-	p = &Pool{
-		config:                config,
-		itemReleasedCh:        make(chan bool),
-		itemDestroyedCh:       make(chan bool),
-		createItemLastErrorCh: make(chan error),
-		ctx:                   ctx,
-		cancel:                cancel,
-		itemCollection:        newCollection(),
-		isInitialized:         false,
-	}
+	pool := newTypedPool[*interface{}](ctx, config, creatorAdapter{factory: config.Factory}, func(object *interface{}) interface{} {
+		return *object
+	})
 
-	if err := config.validate(); err != nil {
-		p.Close()
-		return p, err
-	}
-
-	go p.keepMinCapacity()
-	go p.cleanUp()
-
-	return p, nil
+	return &Pool{pool: pool}, pool.start()
 }
 
 //Get returns Object or error of Object getting/creation
 func (p *Pool) Get() (*interface{}, error) {
-	if p.ctx.Err() == context.Canceled {
-		return nil, errors.New("pool is closed")
-	}
+	return p.pool.Get()
+}
 
-	item, err := p.getIdleItemWithTimeout(p.config.Timeout)
+//GetContext returns Object or error of Object getting/creation. See TypedPool.GetContext
+func (p *Pool) GetContext(ctx context.Context) (*interface{}, error) {
+	return p.pool.GetContext(ctx)
+}
 
-	if err != nil {
-		return nil, err
-	}
-	return item.object, err
+//Release puts Object back to Pool. See TypedPool.Release
+func (p *Pool) Release(object *interface{}) error {
+	return p.pool.Release(object)
 }
 
-//Release puts Object back to Pool
-func (p *Pool) Release(object *interface{}) {
-	p.release(object, true)
+//ReleaseWithError puts Object back to Pool or destroys it depending on err. See TypedPool.ReleaseWithError
+func (p *Pool) ReleaseWithError(object *interface{}, err error) error {
+	return p.pool.ReleaseWithError(object, err)
 }
 
 //Destroy removes and destroys Pool Object
 func (p *Pool) Destroy(object *interface{}) {
-	objectList := []*interface{}{object}
+	p.pool.Destroy(object)
+}
 
-	p.destroy(objectList)
+//Do borrows an Object, calls fn with it and returns the Object back to pool. See TypedPool.Do
+func (p *Pool) Do(ctx context.Context, fn func(object *interface{}) error) error {
+	return p.pool.Do(ctx, fn)
 }
 
 //Len returns pool current length
 func (p *Pool) Len() int {
-	return p.itemCollection.len()
+	return p.pool.Len()
 }
 
-//Close clears and closes pool
-func (p *Pool) Close() error {
-	if p.itemCollection.len() > p.itemCollection.lenIdle() {
-		return errors.New("pool cannot be closed - there are unreleased items")
-	}
-
-	p.cancel()
-
-	p.itemCollection.close()
-	items := p.itemCollection.getAll()
-	for _, item := range items {
-		item.destroy()
-	}
-
-	return nil
+//Leaks returns borrowed Objects which are not released for more than olderThan. See TypedPool.Leaks
+func (p *Pool) Leaks(olderThan time.Duration) []Leak {
+	return p.pool.Leaks(olderThan)
 }
 
-func (p *Pool) release(object *interface{}, updateReleaseTime bool) {
-	key := getObjectKey(object)
-	item := p.itemCollection.get(key)
-
-	if item != nil {
-		if updateReleaseTime {
-			item.release()
-		}
-
-		p.itemCollection.release(key)
-
-		select {
-		case p.itemReleasedCh <- true:
-			break
-		default:
-			break
-		}
-	}
+//WaitReady waits until MinCapacity Objects are created. See TypedPool.WaitReady
+func (p *Pool) WaitReady(ctx context.Context) error {
+	return p.pool.WaitReady(ctx)
 }
 
-func (p *Pool) destroy(objectList []*interface{}) {
-	isItemDestroyed := false
-
-	for _, object := range objectList {
-		key := getObjectKey(object)
-		item := p.itemCollection.get(key)
-
-		if item != nil {
-			item.destroy()
-			p.itemCollection.remove(key)
-
-			isItemDestroyed = true
-		}
-	}
-
-	if isItemDestroyed {
-		select {
-		case p.itemDestroyedCh <- true:
-			break
-		default:
-			break
-		}
-	}
+//Resize changes pool Capacity and MinCapacity. See TypedPool.Resize
+func (p *Pool) Resize(capacity int, minCapacity int) error {
+	return p.pool.Resize(capacity, minCapacity)
 }
 
-func (p *Pool) getIdleItemWithTimeout(timeout time.Duration) (*item, error) {
-	timeoutCtx, cancel := context.WithTimeout(p.ctx, timeout)
-	defer cancel()
-
-	itemCh := make(chan *item)
-	errCh := make(chan error)
-
-	//waiting for idle item
-	go func() {
-		p.RLock()
-		isPollInitialized := p.isInitialized
-		p.RUnlock()
-
-		if isPollInitialized {
-			//try to acquire item immediately
-			if item := p.itemCollection.acquire(); item != nil {
-				itemCh <- item
-				return
-			}
-			go p.putItem()
-		}
-
-		//waiting for idle item or timeout
-		for {
-			select {
-			case <-timeoutCtx.Done():
-				errCh <- TimeoutError
-				return
-			case err := <-p.createItemLastErrorCh:
-				errCh <- err
-				return
-			case <-p.itemReleasedCh:
-				if item := p.itemCollection.acquire(); item != nil {
-					itemCh <- item
-					return
-				}
-				go p.putItem()
-			}
-		}
-	}()
-
-	var item *item
-	var err error
-
-	select {
-	case item = <-itemCh:
-		break
-	case err = <-errCh:
-		break
-	}
-
-	return item, err
+//CircuitState returns state of pool circuit breaker (see Config.CircuitBreaker)
+func (p *Pool) CircuitState() CircuitState {
+	return p.pool.CircuitState()
 }
 
-func (p *Pool) putItem() {
-	p.Lock()
-	defer p.Unlock()
-
-	if p.itemCollection.len() < p.config.Capacity {
-		if item, err := p.createItem(); err == nil {
-			for len(p.createItemLastErrorCh) > 0 {
-				<-p.createItemLastErrorCh
-			}
-
-			if p.itemCollection.put(getObjectKey(item.object), item) {
-				p.release(item.object, true)
-				//we assume that pool is initialized when a first object has been added to pool collection
-				p.isInitialized = true
-			} else {
-				item.destroy()
-			}
-		} else {
-			select {
-			case p.createItemLastErrorCh <- err:
-				break
-			default:
-				break
-			}
-		}
-	}
+//Stats returns pool statistics snapshot
+func (p *Pool) Stats() Stats {
+	return p.pool.Stats()
 }
 
-func (p *Pool) keepMinCapacity() {
-	keepMinCapacity := func() {
-		delta := p.config.MinCapacity - p.itemCollection.len()
-
-		for i := 0; i < delta; i++ {
-			go p.putItem()
-		}
-	}
-
-	keepMinCapacity()
-
-	for {
-		select {
-		case <-p.itemDestroyedCh:
-			keepMinCapacity()
-		case <-p.ctx.Done():
-			return
-		}
-	}
+//Invalidate marks all pool Objects as stale. See TypedPool.Invalidate
+func (p *Pool) Invalidate() {
+	p.pool.Invalidate()
 }
 
-//cleanUp clears inactive pool elements
-func (p *Pool) cleanUp() {
-	if p.config.ItemLifetime == 0 {
-		return
-	}
-
-	ticker := time.NewTicker(p.config.ItemLifetimeCheckPeriod)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ticker.C:
-
-			var itemsToDestroy []*interface{}
-
-			for _, item := range p.itemCollection.acquireAll() {
-				if item.isActive() {
-					p.release(item.object, false)
-				} else {
-					itemsToDestroy = append(itemsToDestroy, item.object)
-				}
-			}
-
-			p.destroy(itemsToDestroy)
-
-		case <-p.ctx.Done():
-			return
-		}
-	}
+//Shutdown closes pool gracefully. See TypedPool.Shutdown
+func (p *Pool) Shutdown(ctx context.Context) error {
+	return p.pool.Shutdown(ctx)
 }
 
-func (p *Pool) createItem() (*item, error) {
-	var err error
-
-	object, err := p.config.Factory.Create(p.ctx)
-
-	if err != nil {
-		return nil, err
-	}
-
-	if reflect.ValueOf(object).Kind() != reflect.Ptr {
-		return nil, errors.New("ggpool.Config.Factory must return object pointer")
-	}
-
-	if _, ok := object.(Object); !ok {
-		return nil, errors.New("ggpool.Config.Factory must create object which implement ggpool.Object interface")
-	}
-
-	return newItem(&object, p.config.ItemLifetime), err
+//Close clears and closes pool
+func (p *Pool) Close() error {
+	return p.pool.Close()
 }
@@ -0,0 +1,148 @@
+package ggpool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zav0x/ggpool"
+)
+
+//RecoveringFactory fails the first failures calls
+type RecoveringFactory struct {
+	MockFactory
+	failures int
+	calls    int
+}
+
+func (f *RecoveringFactory) Create(ctx context.Context) (interface{}, error) {
+	f.Lock()
+	f.calls++
+	isFailed := f.calls <= f.failures
+	f.Unlock()
+
+	if isFailed {
+		return nil, errBackendDown
+	}
+	return CreateMockConnection(&f.MockFactory)
+}
+
+func (f *RecoveringFactory) GetCalls() int {
+	f.RLock()
+	defer f.RUnlock()
+
+	return f.calls
+}
+
+func TestCircuitBreaker(t *testing.T) {
+
+	factory := &RecoveringFactory{failures: 2}
+
+	pool, err := ggpool.NewPool(context.Background(), ggpool.Config{
+		Capacity:    1,
+		MinCapacity: 0,
+		Timeout:     time.Second,
+		CircuitBreaker: ggpool.CircuitBreaker{
+			FailureThreshold: 2,
+			OpenTimeout:      20 * time.Millisecond,
+		},
+		Factory: factory,
+	})
+
+	if err != nil {
+		t.Fatalf("TestCircuitBreaker: Unexpected NewPool() method error: %s", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := pool.Get(); !errors.Is(err, errBackendDown) {
+			t.Fatalf("TestCircuitBreaker: Unexpected Get() method error: %v", err)
+		}
+	}
+
+	assertEqual(t, ggpool.CircuitOpen, pool.CircuitState(), "TestCircuitBreaker: Unexpected circuit state")
+
+	if _, err := pool.Get(); !errors.Is(err, ggpool.ErrCircuitOpen) {
+		t.Fatalf("TestCircuitBreaker: Unexpected Get() method error: %v", err)
+	}
+
+	assertEqual(t, 2, factory.GetCalls(), "TestCircuitBreaker: Factory must not be called while circuit is open")
+
+	//we need to wait for open timeout
+	time.Sleep(25 * time.Millisecond)
+
+	assertEqual(t, ggpool.CircuitHalfOpen, pool.CircuitState(), "TestCircuitBreaker: Unexpected circuit state")
+
+	object, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestCircuitBreaker: Unexpected Get() method error: %s", err)
+	}
+
+	assertEqual(t, ggpool.CircuitClosed, pool.CircuitState(), "TestCircuitBreaker: Unexpected circuit state")
+
+	pool.Release(object)
+	pool.Close()
+}
+
+func TestCircuitBreakerMinCapacity(t *testing.T) {
+
+	pool, err := ggpool.NewPool(context.Background(), ggpool.Config{
+		Capacity:    1,
+		MinCapacity: 1,
+		Timeout:     500 * time.Millisecond,
+		CircuitBreaker: ggpool.CircuitBreaker{
+			FailureThreshold: 1,
+			OpenTimeout:      time.Hour,
+		},
+		Factory: &FailingFactory{},
+	})
+
+	if err != nil {
+		t.Fatalf("TestCircuitBreakerMinCapacity: Unexpected NewPool() method error: %s", err)
+	}
+
+	//background filling of MinCapacity opens the circuit
+	for i := 0; i < 100 && pool.CircuitState() != ggpool.CircuitOpen; i++ {
+		time.Sleep(time.Millisecond)
+	}
+
+	assertEqual(t, ggpool.CircuitOpen, pool.CircuitState(), "TestCircuitBreakerMinCapacity: Unexpected circuit state")
+
+	start := time.Now()
+	_, err = pool.Get()
+
+	assertEqual(t, true, errors.Is(err, ggpool.ErrCircuitOpen), "TestCircuitBreakerMinCapacity: Get() is expected to fail with ErrCircuitOpen")
+	assertEqual(t, true, time.Since(start) < 100*time.Millisecond, "TestCircuitBreakerMinCapacity: Get() is expected to fail fast")
+
+	pool.Close()
+}
+
+func TestBackoff(t *testing.T) {
+
+	factory := &RecoveringFactory{failures: 3}
+
+	pool, err := ggpool.NewPool(context.Background(), ggpool.Config{
+		Capacity:    1,
+		MinCapacity: 1,
+		Timeout:     time.Second,
+		Backoff: ggpool.Backoff{
+			Initial: time.Millisecond,
+			Max:     4 * time.Millisecond,
+			Jitter:  0.5,
+		},
+		Factory: factory,
+	})
+
+	if err != nil {
+		t.Fatalf("TestBackoff: Unexpected NewPool() method error: %s", err)
+	}
+
+	//we need to wait for background creation retries
+	time.Sleep(50 * time.Millisecond)
+
+	assertEqual(t, 4, factory.GetCalls(), "TestBackoff: Unexpected factory calls count")
+	assertEqual(t, 1, pool.Len(), "TestBackoff: Unexpected pool length")
+
+	pool.Close()
+}
@@ -0,0 +1,85 @@
+package ggpool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zav0x/ggpool"
+)
+
+var errNotFound = errors.New("record is not found")
+
+func TestDo(t *testing.T) {
+
+	factory := &MockTypedFactory{}
+
+	pool, err := ggpool.NewTypedPool[*MockConnection](context.Background(), ggpool.Config{
+		Capacity:    1,
+		MinCapacity: 0,
+		Timeout:     time.Second,
+		ErrorClassifier: func(err error) ggpool.ErrorAction {
+			if errors.Is(err, errNotFound) {
+				return ggpool.ActionRelease
+			}
+			return ggpool.ActionDestroy
+		},
+	}, factory)
+
+	if err != nil {
+		t.Fatalf("TestDo: Unexpected NewTypedPool() method error: %s", err)
+	}
+
+	testCases := []struct {
+		description            string
+		err                    error
+		expectedDestroyedCount int
+	}{
+		{"TestDo, case 1: Object is released on success", nil, 0},
+		{"TestDo, case 2: Object is released on not fatal error", errNotFound, 0},
+		{"TestDo, case 3: Object is destroyed on fatal error", errBackendDown, 1},
+	}
+
+	for _, testCase := range testCases {
+		err := pool.Do(context.Background(), func(connection *MockConnection) error {
+			return testCase.err
+		})
+
+		assertEqual(t, testCase.err, err, testCase.description+": Unexpected Do() method error")
+		assertEqual(t, testCase.expectedDestroyedCount, factory.GetDestroyedCount(), testCase.description+": Unexpected destroyed items count")
+		assertEqual(t, pool.Len(), pool.Stats().Idle, testCase.description+": Object is expected to be returned")
+	}
+
+	pool.Close()
+}
+
+func TestDoPanic(t *testing.T) {
+
+	factory := &MockTypedFactory{}
+
+	pool, err := ggpool.NewTypedPool[*MockConnection](context.Background(), ggpool.Config{
+		Capacity:    1,
+		MinCapacity: 0,
+		Timeout:     time.Second,
+	}, factory)
+
+	if err != nil {
+		t.Fatalf("TestDoPanic: Unexpected NewTypedPool() method error: %s", err)
+	}
+
+	func() {
+		defer func() {
+			assertEqual(t, "connection panic", recover(), "TestDoPanic: Panic is expected to be re-raised")
+		}()
+
+		pool.Do(context.Background(), func(connection *MockConnection) error {
+			panic("connection panic")
+		})
+	}()
+
+	assertEqual(t, 1, factory.GetDestroyedCount(), "TestDoPanic: Unexpected destroyed items count")
+	assertEqual(t, 0, pool.Len(), "TestDoPanic: Unexpected pool length")
+
+	pool.Close()
+}
@@ -0,0 +1,91 @@
+package ggpool_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zav0x/ggpool"
+)
+
+func TestMaxExpirationsPerCheck(t *testing.T) {
+
+	factory := &MockTypedFactory{}
+
+	pool, err := ggpool.NewTypedPool[*MockConnection](context.Background(), ggpool.Config{
+		Capacity:                3,
+		MinCapacity:             0,
+		MaxIdleTime:             time.Millisecond,
+		MaxExpirationsPerCheck:  1,
+		ItemLifetimeCheckPeriod: 100 * time.Millisecond,
+		Timeout:                 time.Second,
+	}, factory)
+
+	if err != nil {
+		t.Fatalf("TestMaxExpirationsPerCheck: Unexpected NewTypedPool() method error: %s", err)
+	}
+
+	var connections []*MockConnection
+
+	for i := 0; i < 3; i++ {
+		connection, err := pool.Get()
+
+		if err != nil {
+			t.Fatalf("TestMaxExpirationsPerCheck: Unexpected Get() method error: %s", err)
+		}
+
+		connections = append(connections, connection)
+	}
+
+	for _, connection := range connections {
+		pool.Release(connection)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+
+	assertEqual(t, 1, factory.GetDestroyedCount(), "TestMaxExpirationsPerCheck: Only one item is expected to be destroyed per check")
+	assertEqual(t, 2, pool.Len(), "TestMaxExpirationsPerCheck: Unexpected pool length")
+
+	time.Sleep(250 * time.Millisecond)
+
+	assertEqual(t, 3, factory.GetDestroyedCount(), "TestMaxExpirationsPerCheck: The rest of items are expected to be destroyed by the following checks")
+
+	pool.Close()
+}
+
+func TestLifetimeJitter(t *testing.T) {
+
+	factory := &MockTypedFactory{}
+
+	_, err := ggpool.NewTypedPool[*MockConnection](context.Background(), ggpool.Config{
+		Capacity:                1,
+		MaxLifetime:             time.Second,
+		LifetimeJitter:          1.5,
+		ItemLifetimeCheckPeriod: time.Second,
+	}, factory)
+
+	assertEqual(t, "lifetime jitter value must be from 0 to 1", err.Error(), "TestLifetimeJitter: Unexpected NewTypedPool() method error")
+
+	pool, err := ggpool.NewTypedPool[*MockConnection](context.Background(), ggpool.Config{
+		Capacity:                5,
+		MinCapacity:             5,
+		MaxLifetime:             100 * time.Millisecond,
+		LifetimeJitter:          0.5,
+		ItemLifetimeCheckPeriod: 5 * time.Millisecond,
+		Timeout:                 time.Second,
+		PrefillOnStart:          true,
+	}, factory)
+
+	if err != nil {
+		t.Fatalf("TestLifetimeJitter: Unexpected NewTypedPool() method error: %s", err)
+	}
+
+	//lifetime is only ever reduced by jitter, so no item outlives MaxLifetime
+	time.Sleep(30 * time.Millisecond)
+	assertEqual(t, 0, factory.GetDestroyedCount(), "TestLifetimeJitter: Items are not expected to expire before MaxLifetime reduced by jitter")
+
+	time.Sleep(110 * time.Millisecond)
+	assertEqual(t, true, factory.GetDestroyedCount() >= 5, "TestLifetimeJitter: All items are expected to expire by MaxLifetime")
+
+	pool.Close()
+}
@@ -0,0 +1,116 @@
+package ggpool_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zav0x/ggpool"
+)
+
+//FlakyFactory creates the first object and fails afterwards
+type FlakyFactory struct {
+	MockFactory
+}
+
+func (f *FlakyFactory) Create(ctx context.Context) (interface{}, error) {
+	if f.GetCreatedCount() > 0 {
+		return nil, errBackendDown
+	}
+	return CreateMockConnection(&f.MockFactory)
+}
+
+func TestFactoryErrorBroadcast(t *testing.T) {
+
+	pool, err := ggpool.NewPool(context.Background(), ggpool.Config{
+		Capacity:    5,
+		MinCapacity: 0,
+		Timeout:     time.Second,
+		Factory:     &FailingFactory{},
+	})
+
+	if err != nil {
+		t.Fatalf("TestFactoryErrorBroadcast: Unexpected NewPool() method error: %s", err)
+	}
+
+	var wg sync.WaitGroup
+	errorCh := make(chan error, 5)
+
+	start := time.Now()
+
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := pool.Get()
+			errorCh <- err
+		}()
+	}
+
+	wg.Wait()
+	close(errorCh)
+
+	for err := range errorCh {
+		if !errors.Is(err, errBackendDown) {
+			t.Fatalf("TestFactoryErrorBroadcast: Unexpected Get() method error: %v", err)
+		}
+	}
+
+	if time.Since(start) > 500*time.Millisecond {
+		t.Fatal("TestFactoryErrorBroadcast: Factory error is expected to be returned without waiting for timeout")
+	}
+
+	pool.Close()
+}
+
+func TestKeepWaitingOnFactoryError(t *testing.T) {
+
+	pool, err := ggpool.NewPool(context.Background(), ggpool.Config{
+		Capacity:                  2,
+		MinCapacity:               0,
+		Timeout:                   time.Second,
+		KeepWaitingOnFactoryError: true,
+		Factory:                   &FlakyFactory{},
+	})
+
+	if err != nil {
+		t.Fatalf("TestKeepWaitingOnFactoryError: Unexpected NewPool() method error: %s", err)
+	}
+
+	object, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestKeepWaitingOnFactoryError: Unexpected Get() method error: %s", err)
+	}
+
+	objectCh := make(chan *interface{})
+	errorCh := make(chan error)
+
+	go func() {
+		object, err := pool.Get()
+		if err != nil {
+			errorCh <- err
+		} else {
+			objectCh <- object
+		}
+	}()
+
+	//we need to wait for the failed object creation
+	time.Sleep(5 * time.Millisecond)
+
+	pool.Release(object)
+
+	select {
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("TestKeepWaitingOnFactoryError: test timeout")
+	case err := <-errorCh:
+		t.Fatalf("TestKeepWaitingOnFactoryError: Unexpected Get() method error: %s", err)
+	case released := <-objectCh:
+		assertEqual(t, object, released, "TestKeepWaitingOnFactoryError: Released object is expected")
+	}
+
+	pool.Close()
+}
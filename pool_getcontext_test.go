@@ -0,0 +1,200 @@
+package ggpool_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zav0x/ggpool"
+)
+
+type contextKey string
+
+type ContextFactory struct {
+	MockFactory
+	values chan interface{}
+}
+
+func (f *ContextFactory) Create(ctx context.Context) (interface{}, error) {
+	f.values <- ctx.Value(contextKey("request"))
+	return CreateMockConnection(&f.MockFactory)
+}
+
+type SlowFactory struct {
+	MockFactory
+	delay atomic.Int64
+}
+
+func (f *SlowFactory) Create(ctx context.Context) (*MockConnection, error) {
+	time.Sleep(time.Duration(f.delay.Load()))
+	return CreateMockConnection(&f.MockFactory)
+}
+
+func TestGetContextCancel(t *testing.T) {
+
+	factory := &MockFactory{}
+
+	pool, err := ggpool.NewPool(context.Background(), ggpool.Config{
+		Capacity:                1,
+		MinCapacity:             1,
+		ItemLifetime:            20 * time.Second,
+		ItemLifetimeCheckPeriod: 3 * time.Second,
+		Timeout:                 time.Second,
+		Factory:                 factory,
+	})
+
+	if err != nil {
+		t.Fatalf("TestGetContextCancel: Unexpected NewPool() method error: %s", err)
+	}
+
+	object, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestGetContextCancel: Unexpected Get() method error: %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		time.Sleep(3 * time.Millisecond)
+		cancel()
+	}()
+
+	start := time.Now()
+	_, err = pool.GetContext(ctx)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("TestGetContextCancel: Unexpected GetContext() method error: %v", err)
+	}
+
+	if err == ggpool.TimeoutError {
+		t.Fatal("TestGetContextCancel: Cancellation must be distinguishable from TimeoutError")
+	}
+
+	if time.Since(start) > 500*time.Millisecond {
+		t.Fatal("TestGetContextCancel: GetContext() did not stop waiting on cancellation")
+	}
+
+	pool.Release(object)
+	pool.Close()
+}
+
+func TestGetContextValues(t *testing.T) {
+
+	factory := &ContextFactory{values: make(chan interface{}, 1)}
+
+	pool, err := ggpool.NewPool(context.Background(), ggpool.Config{
+		Capacity:                1,
+		MinCapacity:             0,
+		ItemLifetime:            20 * time.Second,
+		ItemLifetimeCheckPeriod: 3 * time.Second,
+		Timeout:                 time.Second,
+		Factory:                 factory,
+	})
+
+	if err != nil {
+		t.Fatalf("TestGetContextValues: Unexpected NewPool() method error: %s", err)
+	}
+
+	ctx := context.WithValue(context.Background(), contextKey("request"), "request-id")
+
+	object, err := pool.GetContext(ctx)
+
+	if err != nil {
+		t.Fatalf("TestGetContextValues: Unexpected GetContext() method error: %s", err)
+	}
+
+	assertEqual(t, "request-id", <-factory.values, "TestGetContextValues: Unexpected factory context value")
+
+	pool.Release(object)
+	pool.Close()
+}
+
+func TestGetContextSlowFactory(t *testing.T) {
+
+	factory := &SlowFactory{}
+
+	pool, err := ggpool.NewTypedPool[*MockConnection](context.Background(), ggpool.Config{
+		Capacity:    2,
+		MinCapacity: 0,
+		Timeout:     5 * time.Second,
+	}, factory)
+
+	if err != nil {
+		t.Fatalf("TestGetContextSlowFactory: Unexpected NewTypedPool() method error: %s", err)
+	}
+
+	connection, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestGetContextSlowFactory: Unexpected Get() method error: %s", err)
+	}
+
+	factory.delay.Store(int64(time.Second))
+
+	//the Get call stops waiting, but the object creation goes on
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := pool.GetContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("TestGetContextSlowFactory: Unexpected GetContext() method error: %v", err)
+	}
+
+	pool.Release(connection)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	again, err := pool.GetContext(ctx)
+
+	if err != nil {
+		t.Fatalf("TestGetContextSlowFactory: Unexpected GetContext() method error: %s", err)
+	}
+
+	if time.Since(start) > 100*time.Millisecond {
+		t.Fatal("TestGetContextSlowFactory: GetContext() must not wait for creation of another object")
+	}
+
+	assertEqual(t, connection, again, "TestGetContextSlowFactory: Idle object is expected to be reused")
+
+	pool.Release(again)
+	pool.Close()
+}
+
+func TestGetContextPoolTimeout(t *testing.T) {
+
+	pool, err := ggpool.NewTypedPool[*MockConnection](context.Background(), ggpool.Config{
+		Capacity:    1,
+		MinCapacity: 0,
+		Timeout:     20 * time.Millisecond,
+	}, &MockTypedFactory{})
+
+	if err != nil {
+		t.Fatalf("TestGetContextPoolTimeout: Unexpected NewTypedPool() method error: %s", err)
+	}
+
+	connection, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestGetContextPoolTimeout: Unexpected Get() method error: %s", err)
+	}
+
+	//pool timeout is earlier than ctx deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	_, err = pool.GetContext(ctx)
+
+	assertEqual(t, ggpool.ErrTimeout, err, "TestGetContextPoolTimeout: GetContext() is expected to stop waiting at Config.Timeout")
+
+	if time.Since(start) > 500*time.Millisecond {
+		t.Fatal("TestGetContextPoolTimeout: GetContext() did not stop waiting at Config.Timeout")
+	}
+
+	pool.Release(connection)
+	pool.Close()
+}
@@ -0,0 +1,89 @@
+package ggpool_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zav0x/ggpool"
+)
+
+type HookRecorder struct {
+	sync.Mutex
+	events []string
+}
+
+func (r *HookRecorder) record(event string) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.events = append(r.events, event)
+}
+
+func (r *HookRecorder) Events() string {
+	r.Lock()
+	defer r.Unlock()
+
+	return strings.Join(r.events, ",")
+}
+
+func (r *HookRecorder) Hooks() ggpool.Hooks {
+	return ggpool.Hooks{
+		OnCreate:  func(object ggpool.Object) { r.record("create") },
+		OnBorrow:  func(object ggpool.Object) { r.record("borrow") },
+		OnRelease: func(object ggpool.Object) { r.record("release") },
+		OnExpire:  func(object ggpool.Object) { r.record("expire") },
+		OnDestroy: func(object ggpool.Object, reason ggpool.DestroyReason) {
+			r.record(fmt.Sprintf("destroy %s", reason))
+		},
+	}
+}
+
+func TestHooks(t *testing.T) {
+
+	recorder := &HookRecorder{}
+
+	pool, err := ggpool.NewPool(context.Background(), ggpool.Config{
+		Capacity:                1,
+		MinCapacity:             0,
+		ItemLifetime:            5 * time.Millisecond,
+		ItemLifetimeCheckPeriod: time.Millisecond,
+		Timeout:                 time.Second,
+		Hooks:                   recorder.Hooks(),
+		Factory:                 &MockFactory{},
+	})
+
+	if err != nil {
+		t.Fatalf("TestHooks: Unexpected NewPool() method error: %s", err)
+	}
+
+	object, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestHooks: Unexpected Get() method error: %s", err)
+	}
+
+	pool.Release(object)
+
+	//we need to wait for object expiration
+	time.Sleep(20 * time.Millisecond)
+
+	object, err = pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestHooks: Unexpected Get() method error: %s", err)
+	}
+
+	pool.Destroy(object)
+	pool.Close()
+
+	assertEqual(
+		t,
+		"create,borrow,release,expire,destroy expired,create,borrow,destroy explicit",
+		recorder.Events(),
+		"TestHooks: Unexpected hook events",
+	)
+}
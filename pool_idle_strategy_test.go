@@ -0,0 +1,153 @@
+package ggpool_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zav0x/ggpool"
+)
+
+func TestIdleStrategy(t *testing.T) {
+
+	testCases := []struct {
+		description  string
+		strategy     ggpool.IdleStrategy
+		expectedItem int
+	}{
+		{"TestIdleStrategy, case 1: LIFO hands out the most recently released item", ggpool.IdleLIFO, 2},
+		{"TestIdleStrategy, case 2: FIFO hands out the least recently released item", ggpool.IdleFIFO, 0},
+	}
+
+	for _, testCase := range testCases {
+		pool, err := ggpool.NewTypedPool[*MockConnection](context.Background(), ggpool.Config{
+			Capacity:     3,
+			MinCapacity:  0,
+			Timeout:      time.Second,
+			IdleStrategy: testCase.strategy,
+		}, &MockTypedFactory{})
+
+		if err != nil {
+			t.Fatalf("%s: Unexpected NewTypedPool() method error: %s", testCase.description, err)
+		}
+
+		var connections []*MockConnection
+
+		for i := 0; i < 3; i++ {
+			connection, err := pool.Get()
+
+			if err != nil {
+				t.Fatalf("%s: Unexpected Get() method error: %s", testCase.description, err)
+			}
+
+			connections = append(connections, connection)
+		}
+
+		for _, connection := range connections {
+			pool.Release(connection)
+		}
+
+		connection, err := pool.Get()
+
+		if err != nil {
+			t.Fatalf("%s: Unexpected Get() method error: %s", testCase.description, err)
+		}
+
+		assertEqual(t, connections[testCase.expectedItem], connection, testCase.description+": Unexpected item")
+
+		pool.Release(connection)
+		pool.Close()
+	}
+}
+
+func TestIdleStrategyRandom(t *testing.T) {
+
+	pool, err := ggpool.NewTypedPool[*MockConnection](context.Background(), ggpool.Config{
+		Capacity:     3,
+		MinCapacity:  3,
+		Timeout:      time.Second,
+		IdleStrategy: ggpool.IdleRandom,
+	}, &MockTypedFactory{})
+
+	if err != nil {
+		t.Fatalf("TestIdleStrategyRandom: Unexpected NewTypedPool() method error: %s", err)
+	}
+
+	if err := pool.WaitReady(context.Background()); err != nil {
+		t.Fatalf("TestIdleStrategyRandom: Unexpected WaitReady() method error: %s", err)
+	}
+
+	handedOut := map[*MockConnection]bool{}
+
+	for i := 0; i < 100; i++ {
+		connection, err := pool.Get()
+
+		if err != nil {
+			t.Fatalf("TestIdleStrategyRandom: Unexpected Get() method error: %s", err)
+		}
+
+		handedOut[connection] = true
+		pool.Release(connection)
+	}
+
+	assertEqual(t, 3, len(handedOut), "TestIdleStrategyRandom: All items are expected to be handed out")
+
+	pool.Close()
+}
+
+func TestIdleStrategyValidation(t *testing.T) {
+
+	_, err := ggpool.NewTypedPool[*MockConnection](context.Background(), ggpool.Config{
+		Capacity:     1,
+		IdleStrategy: ggpool.IdleStrategy(10),
+	}, &MockTypedFactory{})
+
+	assertEqual(t, "unknown idle strategy", err.Error(), "TestIdleStrategyValidation: Unexpected NewTypedPool() method error")
+}
+
+func TestIdleStrategyMaintenance(t *testing.T) {
+
+	pool, err := ggpool.NewTypedPool[*ValidatedConnection](context.Background(), ggpool.Config{
+		Capacity:                3,
+		MinCapacity:             0,
+		Timeout:                 time.Second,
+		IdleStrategy:            ggpool.IdleLIFO,
+		TestWhileIdle:           true,
+		IdleTestsPerCheck:       1,
+		ItemLifetimeCheckPeriod: 10 * time.Millisecond,
+	}, &ValidatedFactory{})
+
+	if err != nil {
+		t.Fatalf("TestIdleStrategyMaintenance: Unexpected NewTypedPool() method error: %s", err)
+	}
+
+	var connections []*ValidatedConnection
+
+	for i := 0; i < 3; i++ {
+		connection, err := pool.Get()
+
+		if err != nil {
+			t.Fatalf("TestIdleStrategyMaintenance: Unexpected Get() method error: %s", err)
+		}
+
+		connections = append(connections, connection)
+	}
+
+	for _, connection := range connections {
+		pool.Release(connection)
+	}
+
+	//the least recently released items are tested, it must not make them the most recently used ones
+	time.Sleep(50 * time.Millisecond)
+
+	connection, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestIdleStrategyMaintenance: Unexpected Get() method error: %s", err)
+	}
+
+	assertEqual(t, connections[2], connection, "TestIdleStrategyMaintenance: LIFO is expected to hand out the most recently released item")
+
+	pool.Release(connection)
+	pool.Close()
+}
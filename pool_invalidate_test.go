@@ -0,0 +1,109 @@
+package ggpool_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zav0x/ggpool"
+)
+
+type ConcurrencyFactory struct {
+	MockFactory
+	sync.Mutex
+	creating    int
+	maxCreating int
+}
+
+func (f *ConcurrencyFactory) Create(ctx context.Context) (*MockConnection, error) {
+	f.Lock()
+	f.creating++
+	if f.creating > f.maxCreating {
+		f.maxCreating = f.creating
+	}
+	f.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	f.Lock()
+	f.creating--
+	f.Unlock()
+
+	return CreateMockConnection(&f.MockFactory)
+}
+
+func (f *ConcurrencyFactory) GetMaxCreating() int {
+	f.Lock()
+	defer f.Unlock()
+
+	return f.maxCreating
+}
+
+func TestInvalidate(t *testing.T) {
+
+	factory := &MockTypedFactory{}
+
+	pool, err := ggpool.NewTypedPool[*MockConnection](context.Background(), ggpool.Config{
+		Capacity:       3,
+		MinCapacity:    2,
+		PrefillOnStart: true,
+		Timeout:        time.Second,
+	}, factory)
+
+	if err != nil {
+		t.Fatalf("TestInvalidate: Unexpected NewTypedPool() method error: %s", err)
+	}
+
+	borrowed, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestInvalidate: Unexpected Get() method error: %s", err)
+	}
+
+	pool.Invalidate()
+
+	assertEqual(t, 1, factory.GetDestroyedCount(), "TestInvalidate: Idle item is expected to be destroyed")
+	assertEqual(t, 3, factory.GetCreatedCount(), "TestInvalidate: Idle item is expected to be replaced")
+	assertEqual(t, 2, pool.Len(), "TestInvalidate: Unexpected pool length")
+
+	pool.Release(borrowed)
+
+	assertEqual(t, 2, factory.GetDestroyedCount(), "TestInvalidate: Borrowed item is expected to be destroyed on Release")
+
+	connection, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestInvalidate: Unexpected Get() method error: %s", err)
+	}
+
+	assertEqual(t, true, connection != borrowed, "TestInvalidate: Stale item is not expected to be handed out")
+
+	pool.Release(connection)
+	pool.Close()
+}
+
+func TestInvalidateConcurrency(t *testing.T) {
+
+	factory := &ConcurrencyFactory{}
+
+	pool, err := ggpool.NewTypedPool[*MockConnection](context.Background(), ggpool.Config{
+		Capacity:              6,
+		MinCapacity:           6,
+		PrefillOnStart:        true,
+		InvalidateConcurrency: 2,
+		Timeout:               time.Second,
+	}, factory)
+
+	if err != nil {
+		t.Fatalf("TestInvalidateConcurrency: Unexpected NewTypedPool() method error: %s", err)
+	}
+
+	pool.Invalidate()
+
+	assertEqual(t, 6, factory.GetDestroyedCount(), "TestInvalidateConcurrency: All idle items are expected to be replaced")
+	assertEqual(t, 6, pool.Len(), "TestInvalidateConcurrency: Unexpected pool length")
+	assertEqual(t, true, factory.GetMaxCreating() <= 2, "TestInvalidateConcurrency: Items are expected to be replaced with bounded concurrency")
+
+	pool.Close()
+}
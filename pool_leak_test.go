@@ -0,0 +1,74 @@
+package ggpool_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zav0x/ggpool"
+)
+
+func TestLeaks(t *testing.T) {
+
+	var mutex sync.Mutex
+	var leaks []ggpool.Leak
+
+	pool, err := ggpool.NewPool(context.Background(), ggpool.Config{
+		Capacity:                2,
+		MinCapacity:             0,
+		ItemLifetimeCheckPeriod: time.Millisecond,
+		Timeout:                 time.Second,
+		TrackBorrowers:          true,
+		LeakThreshold:           5 * time.Millisecond,
+		Hooks: ggpool.Hooks{
+			OnLeak: func(leak ggpool.Leak) {
+				mutex.Lock()
+				defer mutex.Unlock()
+
+				leaks = append(leaks, leak)
+			},
+		},
+		Factory: &MockFactory{},
+	})
+
+	if err != nil {
+		t.Fatalf("TestLeaks: Unexpected NewPool() method error: %s", err)
+	}
+
+	leaked, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestLeaks: Unexpected Get() method error: %s", err)
+	}
+
+	released, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestLeaks: Unexpected Get() method error: %s", err)
+	}
+
+	pool.Release(released)
+
+	//we need to wait for leak detection
+	time.Sleep(20 * time.Millisecond)
+
+	mutex.Lock()
+	assertEqual(t, 1, len(leaks), "TestLeaks: Leak is expected to be reported once")
+	assertEqual(t, *leaked, interface{}(leaks[0].Object), "TestLeaks: Unexpected leaked object")
+
+	if !strings.Contains(leaks[0].Stack, "TestLeaks") {
+		t.Fatalf("TestLeaks: Borrower stack trace is expected, got:\n%s", leaks[0].Stack)
+	}
+	mutex.Unlock()
+
+	assertEqual(t, 1, len(pool.Leaks(5*time.Millisecond)), "TestLeaks: Unexpected leaks count")
+	assertEqual(t, 0, len(pool.Leaks(time.Hour)), "TestLeaks: Unexpected leaks count")
+
+	pool.Release(leaked)
+
+	assertEqual(t, 0, len(pool.Leaks(0)), "TestLeaks: Unexpected leaks count")
+
+	pool.Close()
+}
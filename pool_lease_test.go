@@ -0,0 +1,81 @@
+package ggpool_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zav0x/ggpool"
+)
+
+func TestMaxBorrowDuration(t *testing.T) {
+
+	factory := &MockTypedFactory{}
+
+	pool, err := ggpool.NewTypedPool[*MockConnection](context.Background(), ggpool.Config{
+		Capacity:                1,
+		MinCapacity:             1,
+		ItemLifetimeCheckPeriod: time.Millisecond,
+		Timeout:                 time.Second,
+		MaxBorrowDuration:       5 * time.Millisecond,
+	}, factory)
+
+	if err != nil {
+		t.Fatalf("TestMaxBorrowDuration: Unexpected NewTypedPool() method error: %s", err)
+	}
+
+	abandoned, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestMaxBorrowDuration: Unexpected Get() method error: %s", err)
+	}
+
+	//the only slot is freed when the lease is expired
+	object, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestMaxBorrowDuration: Unexpected Get() method error: %s", err)
+	}
+
+	if object == abandoned {
+		t.Fatal("TestMaxBorrowDuration: Abandoned object must not be handed out")
+	}
+
+	assertEqual(t, 1, factory.GetDestroyedCount(), "TestMaxBorrowDuration: Unexpected destroyed items count")
+	assertEqual(t, uint64(1), pool.Stats().Abandoned, "TestMaxBorrowDuration: Unexpected abandoned count")
+
+	assertEqual(t, ggpool.ErrAbandoned, pool.Release(abandoned), "TestMaxBorrowDuration: Unexpected Release() method error")
+	assertEqual(t, nil, pool.Release(object), "TestMaxBorrowDuration: Unexpected Release() method error")
+	assertEqual(t, 1, pool.Len(), "TestMaxBorrowDuration: Unexpected pool length")
+
+	pool.Close()
+}
+
+func TestMaxBorrowDurationRetention(t *testing.T) {
+
+	pool, err := ggpool.NewTypedPool[*MockConnection](context.Background(), ggpool.Config{
+		Capacity:                1,
+		MinCapacity:             0,
+		ItemLifetimeCheckPeriod: time.Millisecond,
+		Timeout:                 time.Second,
+		MaxBorrowDuration:       5 * time.Millisecond,
+	}, &MockTypedFactory{})
+
+	if err != nil {
+		t.Fatalf("TestMaxBorrowDurationRetention: Unexpected NewTypedPool() method error: %s", err)
+	}
+
+	abandoned, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestMaxBorrowDurationRetention: Unexpected Get() method error: %s", err)
+	}
+
+	//abandoned object is forgotten after 10 * MaxBorrowDuration, so the pool does not keep it forever
+	time.Sleep(150 * time.Millisecond)
+
+	assertEqual(t, uint64(1), pool.Stats().Abandoned, "TestMaxBorrowDurationRetention: Unexpected abandoned count")
+	assertEqual(t, nil, pool.Release(abandoned), "TestMaxBorrowDurationRetention: Forgotten object is not expected to be reported as abandoned")
+
+	pool.Close()
+}
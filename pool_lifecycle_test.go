@@ -0,0 +1,113 @@
+package ggpool_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zav0x/ggpool"
+)
+
+var errLifecycle = errors.New("lifecycle transition failed")
+
+type LifecycleConnection struct {
+	MockConnection
+	resetCount        int32
+	activateCount     int32
+	passivateCount    int32
+	isActivateBroken  int32
+	isPassivateBroken int32
+}
+
+func (c *LifecycleConnection) Reset() {
+	atomic.AddInt32(&c.resetCount, 1)
+}
+
+func (c *LifecycleConnection) Activate(ctx context.Context) error {
+	atomic.AddInt32(&c.activateCount, 1)
+
+	if atomic.LoadInt32(&c.isActivateBroken) == 1 {
+		return errLifecycle
+	}
+	return nil
+}
+
+func (c *LifecycleConnection) Passivate(ctx context.Context) error {
+	atomic.AddInt32(&c.passivateCount, 1)
+
+	if atomic.LoadInt32(&c.isPassivateBroken) == 1 {
+		return errLifecycle
+	}
+	return nil
+}
+
+type LifecycleFactory struct {
+	MockFactory
+}
+
+func (f *LifecycleFactory) Create(ctx context.Context) (*LifecycleConnection, error) {
+	c, err := CreateMockConnection(&f.MockFactory)
+	return &LifecycleConnection{MockConnection: *c}, err
+}
+
+func TestLifecycle(t *testing.T) {
+
+	factory := &LifecycleFactory{}
+	var destroyReasons []ggpool.DestroyReason
+
+	pool, err := ggpool.NewTypedPool[*LifecycleConnection](context.Background(), ggpool.Config{
+		Capacity:    1,
+		MinCapacity: 0,
+		Timeout:     time.Second,
+		Hooks: ggpool.Hooks{
+			OnDestroy: func(object ggpool.Object, reason ggpool.DestroyReason) {
+				destroyReasons = append(destroyReasons, reason)
+			},
+		},
+	}, factory)
+
+	if err != nil {
+		t.Fatalf("TestLifecycle: Unexpected NewTypedPool() method error: %s", err)
+	}
+
+	connection, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestLifecycle: Unexpected Get() method error: %s", err)
+	}
+
+	assertEqual(t, int32(1), atomic.LoadInt32(&connection.activateCount), "TestLifecycle: Object is expected to be activated on Get")
+
+	pool.Release(connection)
+
+	assertEqual(t, int32(1), atomic.LoadInt32(&connection.resetCount), "TestLifecycle: Object is expected to be reset on Release")
+	assertEqual(t, int32(1), atomic.LoadInt32(&connection.passivateCount), "TestLifecycle: Object is expected to be passivated on Release")
+
+	//broken passivation destroys the object instead of returning it to pool
+	connection, _ = pool.Get()
+	atomic.StoreInt32(&connection.isPassivateBroken, 1)
+	pool.Release(connection)
+
+	assertEqual(t, 1, factory.GetDestroyedCount(), "TestLifecycle: Object is expected to be destroyed on passivation failure")
+	assertEqual(t, ggpool.DestroyPassivationFailed, destroyReasons[len(destroyReasons)-1], "TestLifecycle: Unexpected destroy reason")
+
+	//broken activation destroys the object and Get hands out a new one
+	connection, _ = pool.Get()
+	atomic.StoreInt32(&connection.isActivateBroken, 1)
+	pool.Release(connection)
+
+	next, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestLifecycle: Unexpected Get() method error: %s", err)
+	}
+
+	assertEqual(t, true, next != connection, "TestLifecycle: Object which failed activation is not expected to be handed out")
+	assertEqual(t, 2, factory.GetDestroyedCount(), "TestLifecycle: Object is expected to be destroyed on activation failure")
+	assertEqual(t, ggpool.DestroyActivationFailed, destroyReasons[len(destroyReasons)-1], "TestLifecycle: Unexpected destroy reason")
+
+	pool.Release(next)
+	pool.Close()
+}
@@ -0,0 +1,79 @@
+package ggpool_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zav0x/ggpool"
+)
+
+func TestMaxIdleTime(t *testing.T) {
+
+	factory := &MockTypedFactory{}
+
+	pool, err := ggpool.NewTypedPool[*MockConnection](context.Background(), ggpool.Config{
+		Capacity:                1,
+		MinCapacity:             0,
+		MaxIdleTime:             30 * time.Millisecond,
+		ItemLifetimeCheckPeriod: 5 * time.Millisecond,
+		Timeout:                 time.Second,
+	}, factory)
+
+	if err != nil {
+		t.Fatalf("TestMaxIdleTime: Unexpected NewTypedPool() method error: %s", err)
+	}
+
+	connection, _ := pool.Get()
+
+	//idle time is measured from release, so long borrowing does not expire the object
+	time.Sleep(60 * time.Millisecond)
+	pool.Release(connection)
+
+	assertEqual(t, 0, factory.GetDestroyedCount(), "TestMaxIdleTime: Borrowed item is not expected to be destroyed")
+
+	time.Sleep(100 * time.Millisecond)
+
+	assertEqual(t, 1, factory.GetDestroyedCount(), "TestMaxIdleTime: Idle item is expected to be destroyed")
+	assertEqual(t, uint64(1), pool.Stats().Expirations, "TestMaxIdleTime: Unexpected expirations count")
+
+	pool.Close()
+}
+
+func TestMaxLifetime(t *testing.T) {
+
+	factory := &MockTypedFactory{}
+
+	pool, err := ggpool.NewTypedPool[*MockConnection](context.Background(), ggpool.Config{
+		Capacity:                1,
+		MinCapacity:             0,
+		MaxLifetime:             100 * time.Millisecond,
+		ItemLifetimeCheckPeriod: 5 * time.Millisecond,
+		Timeout:                 time.Second,
+	}, factory)
+
+	if err != nil {
+		t.Fatalf("TestMaxLifetime: Unexpected NewTypedPool() method error: %s", err)
+	}
+
+	connection, _ := pool.Get()
+	time.Sleep(120 * time.Millisecond)
+	pool.Release(connection)
+
+	assertEqual(t, 1, factory.GetDestroyedCount(), "TestMaxLifetime: Item expired while borrowed is expected to be destroyed on Release")
+	assertEqual(t, 0, pool.Len(), "TestMaxLifetime: Unexpected pool length")
+
+	//lifetime is measured from creation, so the object expires although it is released recently
+	connection, _ = pool.Get()
+	pool.Release(connection)
+	time.Sleep(40 * time.Millisecond)
+
+	connection, _ = pool.Get()
+	pool.Release(connection)
+	time.Sleep(150 * time.Millisecond)
+
+	assertEqual(t, 2, factory.GetDestroyedCount(), "TestMaxLifetime: Idle item is expected to be destroyed")
+	assertEqual(t, uint64(2), pool.Stats().Expirations, "TestMaxLifetime: Unexpected expirations count")
+
+	pool.Close()
+}
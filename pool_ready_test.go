@@ -0,0 +1,89 @@
+package ggpool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zav0x/ggpool"
+)
+
+func TestPrefillOnStart(t *testing.T) {
+
+	factory := &MockFactory{}
+
+	pool, err := ggpool.NewPool(context.Background(), ggpool.Config{
+		Capacity:       5,
+		MinCapacity:    3,
+		Timeout:        time.Second,
+		PrefillOnStart: true,
+		Factory:        factory,
+	})
+
+	if err != nil {
+		t.Fatalf("TestPrefillOnStart: Unexpected NewPool() method error: %s", err)
+	}
+
+	assertEqual(t, 3, pool.Len(), "TestPrefillOnStart: Unexpected pool length")
+	assertEqual(t, 3, factory.GetCreatedCount(), "TestPrefillOnStart: Unexpected created items count")
+
+	pool.Close()
+}
+
+func TestPrefillOnStartError(t *testing.T) {
+
+	_, err := ggpool.NewPool(context.Background(), ggpool.Config{
+		Capacity:       5,
+		MinCapacity:    2,
+		Timeout:        time.Second,
+		PrefillOnStart: true,
+		Factory:        &FailingFactory{},
+	})
+
+	var readyErr *ggpool.ReadyError
+	if !errors.As(err, &readyErr) {
+		t.Fatalf("TestPrefillOnStartError: Unexpected NewPool() method error: %v", err)
+	}
+
+	assertEqual(t, 2, len(readyErr.Errors), "TestPrefillOnStartError: Unexpected factory errors count")
+
+	if !errors.Is(err, errBackendDown) {
+		t.Fatalf("TestPrefillOnStartError: Factory error is expected, got: %v", err)
+	}
+
+	var factoryErr *ggpool.FactoryError
+	if !errors.As(err, &factoryErr) {
+		t.Fatalf("TestPrefillOnStartError: FactoryError is expected, got: %v", err)
+	}
+}
+
+func TestWaitReady(t *testing.T) {
+
+	factory := &RecoveringFactory{failures: 2}
+
+	pool, err := ggpool.NewPool(context.Background(), ggpool.Config{
+		Capacity:    1,
+		MinCapacity: 1,
+		Timeout:     time.Second,
+		Backoff: ggpool.Backoff{
+			Initial: time.Millisecond,
+		},
+		Factory: factory,
+	})
+
+	if err != nil {
+		t.Fatalf("TestWaitReady: Unexpected NewPool() method error: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := pool.WaitReady(ctx); err != nil {
+		t.Fatalf("TestWaitReady: Unexpected WaitReady() method error: %s", err)
+	}
+
+	assertEqual(t, 1, pool.Len(), "TestWaitReady: Unexpected pool length")
+
+	pool.Close()
+}
@@ -0,0 +1,107 @@
+package ggpool_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zav0x/ggpool"
+)
+
+func TestRefreshAhead(t *testing.T) {
+
+	factory := &MockTypedFactory{}
+
+	var mutex sync.Mutex
+	destroyReasons := map[ggpool.DestroyReason]int{}
+
+	pool, err := ggpool.NewTypedPool[*MockConnection](context.Background(), ggpool.Config{
+		Capacity:                2,
+		MinCapacity:             2,
+		PrefillOnStart:          true,
+		MaxLifetime:             100 * time.Millisecond,
+		RefreshAhead:            60 * time.Millisecond,
+		ItemLifetimeCheckPeriod: 5 * time.Millisecond,
+		Timeout:                 time.Second,
+		Hooks: ggpool.Hooks{
+			OnDestroy: func(object ggpool.Object, reason ggpool.DestroyReason) {
+				mutex.Lock()
+				defer mutex.Unlock()
+
+				destroyReasons[reason]++
+			},
+		},
+	}, factory)
+
+	if err != nil {
+		t.Fatalf("TestRefreshAhead: Unexpected NewTypedPool() method error: %s", err)
+	}
+
+	//pool length does not drop while items are refreshed
+	for i := 0; i < 20; i++ {
+		assertEqual(t, 2, pool.Len(), "TestRefreshAhead: Unexpected pool length")
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	mutex.Lock()
+	assertEqual(t, true, destroyReasons[ggpool.DestroyRefreshed] >= 2, "TestRefreshAhead: Items are expected to be refreshed")
+	assertEqual(t, 0, destroyReasons[ggpool.DestroyExpired], "TestRefreshAhead: Items are not expected to expire")
+	mutex.Unlock()
+
+	assertEqual(t, uint64(0), pool.Stats().Expirations, "TestRefreshAhead: Unexpected expirations count")
+
+	pool.Close()
+}
+
+func TestRefreshAheadValidation(t *testing.T) {
+
+	testCases := []struct {
+		description   string
+		maxLifetime   time.Duration
+		expectedError string
+	}{
+		{"TestRefreshAheadValidation, case 1: MaxLifetime is not specified", 0, "please specify MaxLifetime for RefreshAhead"},
+		{"TestRefreshAheadValidation, case 2: RefreshAhead equals MaxLifetime", time.Second, "refresh ahead value must be less than max lifetime"},
+		{"TestRefreshAheadValidation, case 3: RefreshAhead exceeds MaxLifetime", time.Millisecond, "refresh ahead value must be less than max lifetime"},
+	}
+
+	for _, testCase := range testCases {
+		_, err := ggpool.NewTypedPool[*MockConnection](context.Background(), ggpool.Config{
+			Capacity:                1,
+			MaxLifetime:             testCase.maxLifetime,
+			RefreshAhead:            time.Second,
+			ItemLifetimeCheckPeriod: time.Second,
+		}, &MockTypedFactory{})
+
+		assertEqual(t, testCase.expectedError, err.Error(), testCase.description+": Unexpected NewTypedPool() method error")
+	}
+}
+
+func TestRefreshAheadLimit(t *testing.T) {
+
+	factory := &MockTypedFactory{}
+
+	pool, err := ggpool.NewTypedPool[*MockConnection](context.Background(), ggpool.Config{
+		Capacity:                3,
+		MinCapacity:             3,
+		PrefillOnStart:          true,
+		MaxLifetime:             time.Second,
+		RefreshAhead:            950 * time.Millisecond,
+		MaxExpirationsPerCheck:  1,
+		ItemLifetimeCheckPeriod: 100 * time.Millisecond,
+		Timeout:                 time.Second,
+	}, factory)
+
+	if err != nil {
+		t.Fatalf("TestRefreshAheadLimit: Unexpected NewTypedPool() method error: %s", err)
+	}
+
+	//all items are aging before the first check, but only one of them is refreshed per check
+	time.Sleep(150 * time.Millisecond)
+
+	assertEqual(t, 1, factory.GetDestroyedCount(), "TestRefreshAheadLimit: Unexpected refreshed items count")
+	assertEqual(t, 3, pool.Len(), "TestRefreshAheadLimit: Unexpected pool length")
+
+	pool.Close()
+}
@@ -0,0 +1,174 @@
+package ggpool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zav0x/ggpool"
+)
+
+var errOverloaded = errors.New("backend is overloaded")
+
+func classifyConnectionError(err error) ggpool.ErrorAction {
+	switch {
+	case errors.Is(err, errNotFound):
+		return ggpool.ActionRelease
+	case errors.Is(err, errOverloaded):
+		return ggpool.ActionQuarantine
+	}
+	return ggpool.ActionDestroy
+}
+
+func TestReleaseWithError(t *testing.T) {
+
+	factory := &MockTypedFactory{}
+
+	pool, err := ggpool.NewTypedPool[*MockConnection](context.Background(), ggpool.Config{
+		Capacity:                1,
+		MinCapacity:             0,
+		Timeout:                 time.Second,
+		ErrorClassifier:         classifyConnectionError,
+		QuarantineDuration:      time.Hour,
+		ItemLifetimeCheckPeriod: time.Hour,
+	}, factory)
+
+	if err != nil {
+		t.Fatalf("TestReleaseWithError: Unexpected NewTypedPool() method error: %s", err)
+	}
+
+	testCases := []struct {
+		description            string
+		err                    error
+		expectedIdle           int
+		expectedQuarantined    int
+		expectedDestroyedCount int
+	}{
+		{"TestReleaseWithError, case 1: Object is released without error", nil, 1, 0, 0},
+		{"TestReleaseWithError, case 2: Object is released on not fatal error", errNotFound, 1, 0, 0},
+		{"TestReleaseWithError, case 3: Object is destroyed on fatal error", errBackendDown, 0, 0, 1},
+		{"TestReleaseWithError, case 4: Object is quarantined", errOverloaded, 0, 1, 1},
+	}
+
+	for _, testCase := range testCases {
+		connection, err := pool.Get()
+
+		if err != nil {
+			t.Fatalf("%s: Unexpected Get() method error: %s", testCase.description, err)
+		}
+
+		if err := pool.ReleaseWithError(connection, testCase.err); err != nil {
+			t.Fatalf("%s: Unexpected ReleaseWithError() method error: %s", testCase.description, err)
+		}
+
+		stats := pool.Stats()
+
+		assertEqual(t, testCase.expectedIdle, stats.Idle, testCase.description+": Unexpected idle items count")
+		assertEqual(t, testCase.expectedQuarantined, stats.Quarantined, testCase.description+": Unexpected quarantined items count")
+		assertEqual(t, 0, stats.InUse, testCase.description+": Unexpected borrowed items count")
+		assertEqual(t, testCase.expectedDestroyedCount, factory.GetDestroyedCount(), testCase.description+": Unexpected destroyed items count")
+	}
+
+	//quarantined object is not available for Get
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = pool.GetContext(ctx)
+	assertEqual(t, true, errors.Is(err, context.DeadlineExceeded), "TestReleaseWithError: Get() is expected to time out")
+
+	if err := pool.Close(); err != nil {
+		t.Fatalf("TestReleaseWithError: Unexpected Close() method error: %s", err)
+	}
+
+	assertEqual(t, 2, factory.GetDestroyedCount(), "TestReleaseWithError: Quarantined item is expected to be destroyed on Close")
+}
+
+func TestQuarantine(t *testing.T) {
+
+	factory := &ValidatedFactory{}
+
+	pool, err := ggpool.NewTypedPool[*ValidatedConnection](context.Background(), ggpool.Config{
+		Capacity:                2,
+		MinCapacity:             0,
+		Timeout:                 time.Second,
+		ErrorClassifier:         classifyConnectionError,
+		QuarantineDuration:      20 * time.Millisecond,
+		ItemLifetimeCheckPeriod: 10 * time.Millisecond,
+	}, factory)
+
+	if err != nil {
+		t.Fatalf("TestQuarantine: Unexpected NewTypedPool() method error: %s", err)
+	}
+
+	healthy, _ := pool.Get()
+	broken, _ := pool.Get()
+
+	broken.Break()
+
+	pool.ReleaseWithError(healthy, errOverloaded)
+	pool.ReleaseWithError(broken, errOverloaded)
+
+	assertEqual(t, 2, pool.Stats().Quarantined, "TestQuarantine: Unexpected quarantined items count")
+
+	time.Sleep(100 * time.Millisecond)
+
+	stats := pool.Stats()
+
+	assertEqual(t, 0, stats.Quarantined, "TestQuarantine: Quarantine is expected to be over")
+	assertEqual(t, 1, stats.Idle, "TestQuarantine: Healthy item is expected to be returned to pool")
+	assertEqual(t, 1, factory.GetDestroyedCount(), "TestQuarantine: Broken item is expected to be destroyed")
+
+	connection, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestQuarantine: Unexpected Get() method error: %s", err)
+	}
+
+	assertEqual(t, healthy, connection, "TestQuarantine: Healthy item is expected to be reused")
+
+	pool.Release(connection)
+	pool.Close()
+}
+
+func TestMaxConsecutiveErrors(t *testing.T) {
+
+	factory := &MockTypedFactory{}
+
+	pool, err := ggpool.NewTypedPool[*MockConnection](context.Background(), ggpool.Config{
+		Capacity:             1,
+		MinCapacity:          0,
+		Timeout:              time.Second,
+		ErrorClassifier:      classifyConnectionError,
+		MaxConsecutiveErrors: 2,
+	}, factory)
+
+	if err != nil {
+		t.Fatalf("TestMaxConsecutiveErrors: Unexpected NewTypedPool() method error: %s", err)
+	}
+
+	testCases := []struct {
+		description            string
+		err                    error
+		expectedDestroyedCount int
+	}{
+		{"TestMaxConsecutiveErrors, case 1: Object is released after first error", errNotFound, 0},
+		{"TestMaxConsecutiveErrors, case 2: Success resets error history", nil, 0},
+		{"TestMaxConsecutiveErrors, case 3: Object is released after first error", errNotFound, 0},
+		{"TestMaxConsecutiveErrors, case 4: Object is destroyed after second consecutive error", errNotFound, 1},
+	}
+
+	for _, testCase := range testCases {
+		connection, err := pool.Get()
+
+		if err != nil {
+			t.Fatalf("%s: Unexpected Get() method error: %s", testCase.description, err)
+		}
+
+		pool.ReleaseWithError(connection, testCase.err)
+
+		assertEqual(t, testCase.expectedDestroyedCount, factory.GetDestroyedCount(), testCase.description+": Unexpected destroyed items count")
+	}
+
+	pool.Close()
+}
@@ -0,0 +1,78 @@
+package ggpool_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zav0x/ggpool"
+)
+
+func TestResize(t *testing.T) {
+
+	factory := &MockTypedFactory{}
+
+	pool, err := ggpool.NewTypedPool[*MockConnection](context.Background(), ggpool.Config{
+		Capacity:    1,
+		MinCapacity: 1,
+		Timeout:     time.Second,
+	}, factory)
+
+	if err != nil {
+		t.Fatalf("TestResize: Unexpected NewTypedPool() method error: %s", err)
+	}
+
+	first, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestResize: Unexpected Get() method error: %s", err)
+	}
+
+	objectCh := make(chan *MockConnection)
+	errorCh := make(chan error)
+
+	go func() {
+		object, err := pool.Get()
+		if err != nil {
+			errorCh <- err
+		} else {
+			objectCh <- object
+		}
+	}()
+
+	//we need to wait for Get to start waiting
+	time.Sleep(3 * time.Millisecond)
+
+	if err := pool.Resize(2, 1); err != nil {
+		t.Fatalf("TestResize: Unexpected Resize() method error: %s", err)
+	}
+
+	var second *MockConnection
+
+	select {
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("TestResize: Waiting Get() is not woken up by Resize()")
+	case err := <-errorCh:
+		t.Fatalf("TestResize: Unexpected Get() method error: %s", err)
+	case second = <-objectCh:
+	}
+
+	assertEqual(t, 2, pool.Len(), "TestResize: Unexpected pool length")
+
+	if err := pool.Resize(1, 0); err != nil {
+		t.Fatalf("TestResize: Unexpected Resize() method error: %s", err)
+	}
+
+	//borrowed objects are retired on release
+	pool.Release(first)
+	pool.Release(second)
+
+	assertEqual(t, 1, pool.Len(), "TestResize: Unexpected pool length")
+	assertEqual(t, 1, factory.GetDestroyedCount(), "TestResize: Unexpected destroyed items count")
+
+	if err := pool.Resize(1, 2); err == nil {
+		t.Fatal("TestResize: Expected Resize() method error")
+	}
+
+	pool.Close()
+}
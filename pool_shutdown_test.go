@@ -0,0 +1,107 @@
+package ggpool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zav0x/ggpool"
+)
+
+func TestShutdown(t *testing.T) {
+
+	factory := &MockTypedFactory{}
+
+	pool, err := ggpool.NewTypedPool[*MockConnection](context.Background(), ggpool.Config{
+		Capacity:    3,
+		MinCapacity: 3,
+		Timeout:     time.Second,
+	}, factory)
+
+	if err != nil {
+		t.Fatalf("TestShutdown: Unexpected NewTypedPool() method error: %s", err)
+	}
+
+	first, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestShutdown: Unexpected Get() method error: %s", err)
+	}
+
+	second, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestShutdown: Unexpected Get() method error: %s", err)
+	}
+
+	errorCh := make(chan error)
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		errorCh <- pool.Shutdown(ctx)
+	}()
+
+	//we need to wait for shutdown start
+	time.Sleep(3 * time.Millisecond)
+
+	if _, err := pool.Get(); err != ggpool.ErrPoolClosed {
+		t.Fatalf("TestShutdown: Unexpected Get() method error: %v", err)
+	}
+
+	assertEqual(t, 2, pool.Len(), "TestShutdown: Idle object is expected to be destroyed")
+
+	pool.Release(first)
+
+	assertEqual(t, 1, pool.Len(), "TestShutdown: Released object is expected to be destroyed")
+
+	err = <-errorCh
+
+	var shutdownErr *ggpool.ShutdownError
+	if !errors.As(err, &shutdownErr) {
+		t.Fatalf("TestShutdown: Unexpected Shutdown() method error: %v", err)
+	}
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("TestShutdown: Unexpected Shutdown() method error: %v", err)
+	}
+
+	assertEqual(t, 1, len(shutdownErr.Unreleased), "TestShutdown: Unexpected unreleased objects count")
+	assertEqual(t, ggpool.Object(second), shutdownErr.Unreleased[0], "TestShutdown: Unexpected unreleased object")
+	assertEqual(t, 0, pool.Len(), "TestShutdown: Unexpected pool length")
+	assertEqual(t, factory.GetCreatedCount(), factory.GetDestroyedCount(), "TestShutdown: Unexpected destroyed items count")
+}
+
+func TestShutdownDrain(t *testing.T) {
+
+	factory := &MockTypedFactory{}
+
+	pool, err := ggpool.NewTypedPool[*MockConnection](context.Background(), ggpool.Config{
+		Capacity:    1,
+		MinCapacity: 1,
+		Timeout:     time.Second,
+	}, factory)
+
+	if err != nil {
+		t.Fatalf("TestShutdownDrain: Unexpected NewTypedPool() method error: %s", err)
+	}
+
+	object, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestShutdownDrain: Unexpected Get() method error: %s", err)
+	}
+
+	go func() {
+		time.Sleep(3 * time.Millisecond)
+		pool.Release(object)
+	}()
+
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatalf("TestShutdownDrain: Unexpected Shutdown() method error: %s", err)
+	}
+
+	assertEqual(t, 1, factory.GetDestroyedCount(), "TestShutdownDrain: Unexpected destroyed items count")
+}
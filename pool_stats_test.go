@@ -0,0 +1,72 @@
+package ggpool_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zav0x/ggpool"
+)
+
+func TestStats(t *testing.T) {
+
+	factory := &MockFactory{}
+
+	pool, err := ggpool.NewPool(context.Background(), ggpool.Config{
+		Capacity:    1,
+		MinCapacity: 0,
+		Timeout:     5 * time.Millisecond,
+		Factory:     factory,
+	})
+
+	if err != nil {
+		t.Fatalf("TestStats: Unexpected NewPool() method error: %s", err)
+	}
+
+	//miss, new object is created
+	object, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestStats: Unexpected Get() method error: %s", err)
+	}
+
+	//miss, timeout
+	if _, err := pool.Get(); err != ggpool.TimeoutError {
+		t.Fatalf("TestStats: Unexpected Get() method error: %v", err)
+	}
+
+	stats := pool.Stats()
+
+	assertEqual(t, 1, stats.Total, "TestStats: Unexpected total count")
+	assertEqual(t, 0, stats.Idle, "TestStats: Unexpected idle count")
+	assertEqual(t, 1, stats.InUse, "TestStats: Unexpected in-use count")
+
+	pool.Release(object)
+
+	//hit
+	object, err = pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestStats: Unexpected Get() method error: %s", err)
+	}
+
+	pool.Destroy(object)
+
+	stats = pool.Stats()
+
+	assertEqual(t, 0, stats.Total, "TestStats: Unexpected total count")
+	assertEqual(t, 0, stats.Waiting, "TestStats: Unexpected waiting count")
+	assertEqual(t, uint64(3), stats.Gets, "TestStats: Unexpected gets count")
+	assertEqual(t, uint64(1), stats.Hits, "TestStats: Unexpected hits count")
+	assertEqual(t, uint64(2), stats.Misses, "TestStats: Unexpected misses count")
+	assertEqual(t, uint64(1), stats.Timeouts, "TestStats: Unexpected timeouts count")
+	assertEqual(t, uint64(1), stats.Creates, "TestStats: Unexpected creates count")
+	assertEqual(t, uint64(0), stats.CreateFailures, "TestStats: Unexpected create failures count")
+	assertEqual(t, uint64(1), stats.Destroys, "TestStats: Unexpected destroys count")
+
+	if stats.WaitDuration < 5*time.Millisecond {
+		t.Fatalf("TestStats: Unexpected wait duration: %s", stats.WaitDuration)
+	}
+
+	pool.Close()
+}
@@ -66,3 +66,11 @@ func assertEqual(t *testing.T, expected interface{}, actual interface{}, message
 		t.Fatalf("%s. Expected: %v, Actual: %v", message, expected, actual)
 	}
 }
+
+type MockTypedFactory struct {
+	MockFactory
+}
+
+func (f *MockTypedFactory) Create(ctx context.Context) (*MockConnection, error) {
+	return CreateMockConnection(&f.MockFactory)
+}
@@ -0,0 +1,105 @@
+package ggpool_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zav0x/ggpool"
+)
+
+type SameObjectFactory struct {
+	MockFactory
+	sync.Mutex
+	connection *MockConnection
+}
+
+func (f *SameObjectFactory) Create(ctx context.Context) (*MockConnection, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	if f.connection == nil {
+		f.connection, _ = CreateMockConnection(&f.MockFactory)
+	}
+	return f.connection, nil
+}
+
+func TestTypedPool(t *testing.T) {
+
+	factory := &MockTypedFactory{}
+
+	pool, err := ggpool.NewTypedPool[*MockConnection](context.Background(), ggpool.Config{
+		Capacity:                2,
+		MinCapacity:             1,
+		ItemLifetime:            20 * time.Second,
+		ItemLifetimeCheckPeriod: 3 * time.Second,
+		Timeout:                 5 * time.Millisecond,
+	}, factory)
+
+	if err != nil {
+		t.Fatalf("TestTypedPool: Unexpected NewTypedPool() method error: %s", err)
+	}
+
+	connection, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestTypedPool: Unexpected Get() method error: %s", err)
+	}
+
+	if connection == nil {
+		t.Fatal("TestTypedPool: Unexpected nil object")
+	}
+
+	pool.Release(connection)
+
+	again, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestTypedPool: Unexpected Get() method error: %s", err)
+	}
+
+	assertEqual(t, connection, again, "TestTypedPool: Released object is expected to be reused")
+
+	pool.Destroy(again)
+
+	assertEqual(
+		t,
+		1,
+		factory.GetDestroyedCount(),
+		"TestTypedPool: Unexpected destroyed items count",
+	)
+
+	pool.Close()
+}
+
+func TestTypedPoolDuplicateObject(t *testing.T) {
+
+	factory := &SameObjectFactory{}
+
+	pool, err := ggpool.NewTypedPool[*MockConnection](context.Background(), ggpool.Config{
+		Capacity:    2,
+		MinCapacity: 0,
+		Timeout:     time.Second,
+	}, factory)
+
+	if err != nil {
+		t.Fatalf("TestTypedPoolDuplicateObject: Unexpected NewTypedPool() method error: %s", err)
+	}
+
+	connection, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestTypedPoolDuplicateObject: Unexpected Get() method error: %s", err)
+	}
+
+	_, err = pool.Get()
+
+	assertEqual(t, true, errors.Is(err, ggpool.ErrInvalidObject), "TestTypedPoolDuplicateObject: Duplicate object is expected to be rejected")
+	assertEqual(t, 1, pool.Len(), "TestTypedPoolDuplicateObject: Unexpected pool length")
+	assertEqual(t, 1, factory.GetDestroyedCount(), "TestTypedPoolDuplicateObject: Duplicate object is expected to be destroyed")
+
+	pool.Release(connection)
+	pool.Close()
+}
@@ -0,0 +1,150 @@
+package ggpool_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zav0x/ggpool"
+)
+
+type ValidatedConnection struct {
+	MockConnection
+	isBroken int32
+}
+
+func (c *ValidatedConnection) Break() {
+	atomic.StoreInt32(&c.isBroken, 1)
+}
+
+func (c *ValidatedConnection) Validate(ctx context.Context) error {
+	if atomic.LoadInt32(&c.isBroken) == 1 {
+		return errors.New("connection is broken")
+	}
+	return nil
+}
+
+type ValidatedFactory struct {
+	MockFactory
+}
+
+func (f *ValidatedFactory) Create(ctx context.Context) (*ValidatedConnection, error) {
+	c, err := CreateMockConnection(&f.MockFactory)
+	return &ValidatedConnection{MockConnection: *c}, err
+}
+
+func TestTestOnBorrow(t *testing.T) {
+
+	factory := &ValidatedFactory{}
+
+	pool, err := ggpool.NewTypedPool[*ValidatedConnection](context.Background(), ggpool.Config{
+		Capacity:     1,
+		MinCapacity:  1,
+		Timeout:      time.Second,
+		TestOnBorrow: true,
+	}, factory)
+
+	if err != nil {
+		t.Fatalf("TestTestOnBorrow: Unexpected NewTypedPool() method error: %s", err)
+	}
+
+	connection, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestTestOnBorrow: Unexpected Get() method error: %s", err)
+	}
+
+	connection.Break()
+	pool.Release(connection)
+
+	another, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestTestOnBorrow: Unexpected Get() method error: %s", err)
+	}
+
+	if another == connection {
+		t.Fatal("TestTestOnBorrow: Broken object must not be handed out")
+	}
+
+	assertEqual(t, 1, factory.GetDestroyedCount(), "TestTestOnBorrow: Unexpected destroyed items count")
+
+	pool.Release(another)
+	pool.Close()
+}
+
+func TestTestOnReturn(t *testing.T) {
+
+	factory := &ValidatedFactory{}
+
+	pool, err := ggpool.NewTypedPool[*ValidatedConnection](context.Background(), ggpool.Config{
+		Capacity:     1,
+		MinCapacity:  0,
+		Timeout:      time.Second,
+		TestOnReturn: true,
+	}, factory)
+
+	if err != nil {
+		t.Fatalf("TestTestOnReturn: Unexpected NewTypedPool() method error: %s", err)
+	}
+
+	connection, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestTestOnReturn: Unexpected Get() method error: %s", err)
+	}
+
+	connection.Break()
+	pool.Release(connection)
+
+	assertEqual(t, 1, factory.GetDestroyedCount(), "TestTestOnReturn: Unexpected destroyed items count")
+	assertEqual(t, 0, pool.Len(), "TestTestOnReturn: Unexpected pool length")
+
+	pool.Close()
+}
+
+func TestTestWhileIdle(t *testing.T) {
+
+	factory := &ValidatedFactory{}
+
+	pool, err := ggpool.NewTypedPool[*ValidatedConnection](context.Background(), ggpool.Config{
+		Capacity:                2,
+		MinCapacity:             2,
+		ItemLifetimeCheckPeriod: time.Millisecond,
+		Timeout:                 time.Second,
+		TestWhileIdle:           true,
+		IdleTestsPerCheck:       1,
+		IdleTestTimeout:         time.Millisecond,
+	}, factory)
+
+	if err != nil {
+		t.Fatalf("TestTestWhileIdle: Unexpected NewTypedPool() method error: %s", err)
+	}
+
+	first, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestTestWhileIdle: Unexpected Get() method error: %s", err)
+	}
+
+	second, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestTestWhileIdle: Unexpected Get() method error: %s", err)
+	}
+
+	first.Break()
+	pool.Release(first)
+	pool.Release(second)
+
+	//we need to wait for idle check and min capacity restoring
+	time.Sleep(20 * time.Millisecond)
+
+	assertEqual(t, 1, factory.GetDestroyedCount(), "TestTestWhileIdle: Unexpected destroyed items count")
+	assertEqual(t, 3, factory.GetCreatedCount(), "TestTestWhileIdle: Unexpected created items count")
+	assertEqual(t, 2, pool.Len(), "TestTestWhileIdle: Unexpected pool length")
+
+	pool.Close()
+}
@@ -0,0 +1,115 @@
+package ggpool_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zav0x/ggpool"
+)
+
+func waitForWaiters(t *testing.T, pool *ggpool.TypedPool[*MockConnection], expected int) {
+	for i := 0; i < 100 && pool.Stats().Waiting != expected; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	assertEqual(t, expected, pool.Stats().Waiting, "waitForWaiters: Unexpected waiting Get calls count")
+}
+
+func TestWaitQueue(t *testing.T) {
+
+	pool, err := ggpool.NewTypedPool[*MockConnection](context.Background(), ggpool.Config{
+		Capacity:    1,
+		MinCapacity: 0,
+		Timeout:     time.Second,
+	}, &MockTypedFactory{})
+
+	if err != nil {
+		t.Fatalf("TestWaitQueue: Unexpected NewTypedPool() method error: %s", err)
+	}
+
+	connection, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestWaitQueue: Unexpected Get() method error: %s", err)
+	}
+
+	order := make(chan int, 5)
+
+	//waiters are queued one by one, so their arrival order is known
+	for i := 0; i < 5; i++ {
+		go func(i int) {
+			connection, err := pool.Get()
+
+			if err != nil {
+				t.Errorf("TestWaitQueue: Unexpected Get() method error: %s", err)
+				order <- -1
+				return
+			}
+
+			order <- i
+			pool.Release(connection)
+		}(i)
+
+		waitForWaiters(t, pool, i+1)
+	}
+
+	pool.Release(connection)
+
+	for i := 0; i < 5; i++ {
+		assertEqual(t, i, <-order, "TestWaitQueue: Object is expected to be handed out to the longest waiting Get call")
+	}
+
+	assertEqual(t, 0, pool.Stats().Waiting, "TestWaitQueue: Unexpected waiting Get calls count")
+
+	pool.Close()
+}
+
+func TestWaitQueueCancel(t *testing.T) {
+
+	pool, err := ggpool.NewTypedPool[*MockConnection](context.Background(), ggpool.Config{
+		Capacity:    1,
+		MinCapacity: 0,
+		Timeout:     time.Second,
+	}, &MockTypedFactory{})
+
+	if err != nil {
+		t.Fatalf("TestWaitQueueCancel: Unexpected NewTypedPool() method error: %s", err)
+	}
+
+	connection, err := pool.Get()
+
+	if err != nil {
+		t.Fatalf("TestWaitQueueCancel: Unexpected Get() method error: %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := make(chan error)
+
+	go func() {
+		_, err := pool.GetContext(ctx)
+		cancelled <- err
+	}()
+
+	waitForWaiters(t, pool, 1)
+
+	received := make(chan *MockConnection)
+
+	go func() {
+		connection, _ := pool.Get()
+		received <- connection
+	}()
+
+	waitForWaiters(t, pool, 2)
+
+	//the first waiter leaves the queue, so the Object goes to the second one
+	cancel()
+	<-cancelled
+
+	pool.Release(connection)
+
+	assertEqual(t, connection, <-received, "TestWaitQueueCancel: Object is expected to be handed out to the remaining Get call")
+	assertEqual(t, 0, pool.Stats().Waiting, "TestWaitQueueCancel: Unexpected waiting Get calls count")
+
+	pool.Release(connection)
+	pool.Close()
+}
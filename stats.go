@@ -0,0 +1,71 @@
+package ggpool
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+//Stats is a snapshot of pool statistics
+type Stats struct {
+	//Number of Objects in pool
+	Total int
+	//Number of idle Objects
+	Idle int
+	//Number of borrowed Objects
+	InUse int
+	//Number of quarantined Objects (see ActionQuarantine)
+	Quarantined int
+	//Number of Get calls in the wait queue. Released and created Objects are handed out to them in order of arrival
+	Waiting int
+
+	//Number of Get calls
+	Gets uint64
+	//Number of times an idle Object was acquired without waiting
+	Hits uint64
+	//Number of times Get had to wait for an Object
+	Misses uint64
+	//Number of Get calls which failed because of timeout or context cancellation
+	Timeouts uint64
+	//Number of created Objects
+	Creates uint64
+	//Number of failed Object creations
+	CreateFailures uint64
+	//Number of destroyed Objects
+	Destroys uint64
+	//Number of Objects destroyed because MaxIdleTime or MaxLifetime is exceeded
+	Expirations uint64
+	//Number of borrowed Objects destroyed because MaxBorrowDuration is exceeded
+	Abandoned uint64
+
+	//Total time Get calls spent on getting Objects
+	WaitDuration time.Duration
+}
+
+//stats holds pool cumulative counters
+type stats struct {
+	gets           atomic.Uint64
+	hits           atomic.Uint64
+	misses         atomic.Uint64
+	timeouts       atomic.Uint64
+	creates        atomic.Uint64
+	createFailures atomic.Uint64
+	destroys       atomic.Uint64
+	expirations    atomic.Uint64
+	abandoned      atomic.Uint64
+	waitDuration   atomic.Int64
+}
+
+func (s *stats) snapshot() Stats {
+	return Stats{
+		Gets:           s.gets.Load(),
+		Hits:           s.hits.Load(),
+		Misses:         s.misses.Load(),
+		Timeouts:       s.timeouts.Load(),
+		Creates:        s.creates.Load(),
+		CreateFailures: s.createFailures.Load(),
+		Destroys:       s.destroys.Load(),
+		Expirations:    s.expirations.Load(),
+		Abandoned:      s.abandoned.Load(),
+		WaitDuration:   time.Duration(s.waitDuration.Load()),
+	}
+}
@@ -0,0 +1,1137 @@
+package ggpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+//TypedPool is a pool of objects of type T
+type TypedPool[T comparable] struct {
+	config          Config
+	factory         TypedCreator[T]
+	unwrap          func(T) interface{}
+	metrics         MetricsRecorder
+	itemDestroyedCh chan bool
+	createErrors    *errorBroadcast
+	breaker         *circuitBreaker
+	shutdownCh      chan bool
+	ctx             context.Context
+	cancel          context.CancelFunc
+
+	sync.RWMutex
+	itemCollection *collection[T]
+	limiter        capacityLimiter
+	fillMutex      sync.Mutex
+
+	//isInitialized is read by Get without the lock, so Get doesn't wait for item creation which holds the lock
+	isInitialized atomic.Bool
+
+	//capacity and minCapacity are initialized by Config and can be changed by Resize
+	capacity    atomic.Int64
+	minCapacity atomic.Int64
+
+	stats stats
+
+	//createAttempts is number of consecutive failed factory calls
+	createAttempts atomic.Int64
+
+	//generation is incremented by Invalidate. Items of older generations are stale
+	generation atomic.Int64
+}
+
+//NewTypedPool returns a new TypedPool instance. Config.Factory is ignored, objects are created by factory
+func NewTypedPool[T comparable](ctx context.Context, config Config, factory TypedCreator[T]) (*TypedPool[T], error) {
+	p := newTypedPool(ctx, config, factory, func(value T) interface{} {
+		return value
+	})
+
+	return p, p.start()
+}
+
+//newTypedPool returns a new TypedPool instance which is not started yet. unwrap returns the Object which is held by the pool value
+func newTypedPool[T comparable](ctx context.Context, config Config, factory TypedCreator[T], unwrap func(T) interface{}) *TypedPool[T] {
+	var p *TypedPool[T]
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	p = &TypedPool[T]{
+		config:          config,
+		factory:         factory,
+		unwrap:          unwrap,
+		metrics:         config.Metrics,
+		itemDestroyedCh: make(chan bool, 1),
+		createErrors:    newErrorBroadcast(),
+		breaker:         newCircuitBreaker(config.CircuitBreaker),
+		shutdownCh:      make(chan bool, 1),
+		ctx:             ctx,
+		cancel:          cancel,
+		itemCollection:  newCollection[T](config.IdleStrategy),
+	}
+
+	//there is nothing to wait for when pool doesn't keep min capacity
+	p.isInitialized.Store(config.MinCapacity == 0)
+
+	if p.metrics == nil {
+		p.metrics = noopMetrics{}
+	}
+
+	p.capacity.Store(int64(config.Capacity))
+	p.minCapacity.Store(int64(config.MinCapacity))
+
+	return p
+}
+
+//start validates pool configuration and starts pool background routines
+func (p *TypedPool[T]) start() error {
+	if err := p.config.validate(); err != nil {
+		p.Close()
+		return err
+	}
+
+	if p.config.PrefillOnStart {
+		ctx := p.ctx
+
+		if p.config.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, p.config.Timeout)
+			defer cancel()
+		}
+
+		if err := p.WaitReady(ctx); err != nil {
+			p.Close()
+			return err
+		}
+	}
+
+	go p.keepMinCapacity()
+	go p.cleanUp()
+
+	return nil
+}
+
+//Get returns Object or error of Object getting/creation.
+//Get waits for an Object no longer than Config.Timeout
+func (p *TypedPool[T]) Get() (T, error) {
+	return p.GetContext(context.Background())
+}
+
+//GetContext returns Object or error of Object getting/creation.
+//GetContext stops waiting for an Object when ctx is done and returns ctx.Err() wrapped into the error.
+//Config.Timeout is applied even if ctx has a later deadline. Values of ctx are passed to the factory when a new Object is created
+func (p *TypedPool[T]) GetContext(ctx context.Context) (T, error) {
+	var zero T
+
+	if p.ctx.Err() == context.Canceled {
+		return zero, ErrClosed
+	}
+
+	p.stats.gets.Add(1)
+
+	start := time.Now()
+	defer func() {
+		waitDuration := time.Since(start)
+
+		p.stats.waitDuration.Add(int64(waitDuration))
+		p.metrics.ObserveWait(p.config.Name, waitDuration)
+		p.recordSize()
+	}()
+
+	//waiting ends at the deadline of ctx or at Config.Timeout whichever comes first
+	waitCtx, cancel := context.WithTimeout(ctx, p.config.Timeout)
+	defer cancel()
+
+	for {
+		item, err := p.getIdleItem(ctx, waitCtx)
+
+		if err != nil {
+			return zero, err
+		}
+
+		if p.isStale(item) {
+			p.destroy([]T{item.value}, DestroyInvalidated)
+			continue
+		}
+
+		if p.config.TestOnBorrow {
+			if err := validate(waitCtx, item.object); err != nil {
+				p.destroy([]T{item.value}, DestroyValidationFailed)
+				continue
+			}
+		}
+
+		if err := activate(waitCtx, item.object); err != nil {
+			p.destroy([]T{item.value}, DestroyActivationFailed)
+			continue
+		}
+
+		var stack string
+		if p.config.TrackBorrowers {
+			stack = string(debug.Stack())
+		}
+
+		item.borrow(stack)
+		p.config.Hooks.onBorrow(item.object)
+
+		return item.value, nil
+	}
+}
+
+//Release puts Object back to Pool. Object is reset and passivated before that (see Resetter and Passivator).
+//It returns ErrAbandoned if Object has been destroyed because Config.MaxBorrowDuration is exceeded
+func (p *TypedPool[T]) Release(object T) error {
+	return p.ReleaseWithError(object, nil)
+}
+
+//ReleaseWithError puts Object which has failed with err back to Pool.
+//Config.ErrorClassifier decides whether the Object is returned to pool, destroyed or quarantined.
+//The Object is destroyed after Config.MaxConsecutiveErrors errors in a row. Nil err resets Object error history.
+//It returns ErrAbandoned if Object has been destroyed because Config.MaxBorrowDuration is exceeded
+func (p *TypedPool[T]) ReleaseWithError(object T, err error) error {
+	item := p.itemCollection.get(object)
+
+	if item == nil {
+		if p.itemCollection.reclaim(object) {
+			return ErrAbandoned
+		}
+		return nil
+	}
+
+	p.metrics.ObserveBorrow(p.config.Name, item.borrowDuration())
+
+	action := ActionRelease
+
+	if err != nil {
+		action = p.config.ErrorClassifier.classify(err)
+
+		if errorCount := item.failure(); p.config.MaxConsecutiveErrors > 0 && errorCount >= p.config.MaxConsecutiveErrors {
+			action = ActionDestroy
+		}
+	} else {
+		item.success()
+	}
+
+	//pool is being shut down
+	if p.ctx.Err() != nil {
+		p.destroy([]T{object}, DestroyPoolClosing)
+		return nil
+	}
+
+	if action == ActionDestroy || (action == ActionQuarantine && p.config.QuarantineDuration == 0) {
+		p.destroy([]T{object}, DestroyFailed)
+		return nil
+	}
+
+	if p.isStale(item) {
+		p.destroy([]T{object}, DestroyInvalidated)
+		return nil
+	}
+
+	//object lifetime has expired while it has been borrowed
+	if item.isAged() {
+		p.stats.expirations.Add(1)
+		p.config.Hooks.onExpire(item.object)
+		p.destroy([]T{object}, DestroyExpired)
+		return nil
+	}
+
+	//pool capacity has been reduced by Resize, so the object is retired
+	if p.itemCollection.len() > int(p.capacity.Load()) {
+		p.destroy([]T{object}, DestroyResized)
+		return nil
+	}
+
+	if action != ActionQuarantine && p.config.TestOnReturn {
+		if err := validate(p.ctx, item.object); err != nil {
+			p.destroy([]T{object}, DestroyValidationFailed)
+			return nil
+		}
+	}
+
+	if err := passivate(p.ctx, item.object); err != nil {
+		p.destroy([]T{object}, DestroyPassivationFailed)
+		return nil
+	}
+
+	if action == ActionQuarantine {
+		p.quarantine(item)
+		return nil
+	}
+
+	p.release(object, true)
+	p.config.Hooks.onRelease(item.object)
+
+	return nil
+}
+
+//Destroy removes and destroys Pool Object
+func (p *TypedPool[T]) Destroy(object T) {
+	p.destroyBorrowed(object, DestroyExplicit)
+}
+
+//Do borrows an Object, calls fn with it and returns the Object back to pool.
+//The error returned by fn is handled as in ReleaseWithError. If fn panics then the Object is destroyed
+func (p *TypedPool[T]) Do(ctx context.Context, fn func(object T) error) error {
+	object, err := p.GetContext(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	isReturned := false
+
+	defer func() {
+		//fn panicked, the panic goes on after the object is destroyed
+		if !isReturned {
+			p.destroyBorrowed(object, DestroyFailed)
+		}
+	}()
+
+	err = fn(object)
+	isReturned = true
+
+	if releaseErr := p.ReleaseWithError(object, err); err == nil {
+		err = releaseErr
+	}
+	return err
+}
+
+//Len returns pool current length
+func (p *TypedPool[T]) Len() int {
+	return p.itemCollection.len()
+}
+
+//Shutdown closes pool gracefully.
+//New Get calls return ErrClosed immediately, idle Objects are destroyed and borrowed ones are destroyed on Release.
+//Shutdown waits until all borrowed Objects are released. When ctx is done the remaining Objects are destroyed and ShutdownError is returned
+func (p *TypedPool[T]) Shutdown(ctx context.Context) error {
+	p.cancel()
+	p.itemCollection.close()
+
+	for {
+		var itemsToDestroy []T
+
+		for _, item := range p.itemCollection.acquireAll() {
+			itemsToDestroy = append(itemsToDestroy, item.value)
+		}
+
+		//quarantine of every item ends before now + QuarantineDuration
+		for _, item := range p.itemCollection.unquarantine(time.Now().UTC().Add(p.config.QuarantineDuration)) {
+			itemsToDestroy = append(itemsToDestroy, item.value)
+		}
+
+		p.destroy(itemsToDestroy, DestroyPoolClosing)
+
+		if p.itemCollection.len() == 0 {
+			return nil
+		}
+
+		select {
+		case <-p.shutdownCh:
+		case <-ctx.Done():
+			var unreleased []Object
+			var itemsToDestroy []T
+
+			for _, item := range p.itemCollection.getAll() {
+				unreleased = append(unreleased, item.object)
+				itemsToDestroy = append(itemsToDestroy, item.value)
+			}
+
+			p.destroy(itemsToDestroy, DestroyPoolClosing)
+
+			if len(unreleased) == 0 {
+				return nil
+			}
+			return &ShutdownError{Err: ctx.Err(), Unreleased: unreleased}
+		}
+	}
+}
+
+//abandonedRetention is number of MaxBorrowDuration periods during which abandoned items are remembered (see ErrAbandoned)
+const abandonedRetention = 10
+
+//checkLeases destroys items which are borrowed for more than Config.MaxBorrowDuration
+func (p *TypedPool[T]) checkLeases() {
+	if p.config.MaxBorrowDuration == 0 {
+		return
+	}
+
+	isItemDestroyed := false
+
+	for _, item := range p.itemCollection.getBorrowed() {
+		if _, ok := item.leak(p.config.MaxBorrowDuration); !ok {
+			continue
+		}
+
+		//item could be released since it was got from collection
+		if p.itemCollection.abandon(item.value) {
+			p.stats.abandoned.Add(1)
+			p.destroyItem(item, DestroyAbandoned)
+
+			isItemDestroyed = true
+		}
+	}
+
+	if isItemDestroyed {
+		p.itemsDestroyed()
+	}
+
+	p.itemCollection.forgetAbandoned(time.Now().UTC().Add(-abandonedRetention * p.config.MaxBorrowDuration))
+}
+
+//Leaks returns borrowed Objects which are not released for more than olderThan
+func (p *TypedPool[T]) Leaks(olderThan time.Duration) []Leak {
+	var leaks []Leak
+
+	for _, item := range p.itemCollection.getBorrowed() {
+		if leak, ok := item.leak(olderThan); ok {
+			leaks = append(leaks, leak)
+		}
+	}
+	return leaks
+}
+
+//WaitReady waits until MinCapacity Objects are created.
+//If Config.Backoff is specified then failed creations are retried until ctx is done, otherwise WaitReady returns after the first failed attempt.
+//ReadyError with all factory errors is returned if pool is not ready
+func (p *TypedPool[T]) WaitReady(ctx context.Context) error {
+	createCtx := valueContext{Context: p.ctx, values: ctx}
+
+	var errs []error
+	attempt := 0
+
+	for {
+		if p.ctx.Err() != nil {
+			return ErrClosed
+		}
+
+		fillCh := make(chan []error, 1)
+		go func() {
+			fillCh <- p.fillMinCapacity(createCtx)
+		}()
+
+		select {
+		case fillErrs := <-fillCh:
+			errs = append(errs, fillErrs...)
+		case <-ctx.Done():
+			return &ReadyError{Err: ctx.Err(), Errors: errs}
+		}
+
+		if p.itemCollection.len() >= int(p.minCapacity.Load()) {
+			return nil
+		}
+
+		if p.config.Backoff.Initial == 0 {
+			return &ReadyError{Errors: errs}
+		}
+
+		attempt++
+
+		select {
+		case <-time.After(p.config.Backoff.delay(attempt)):
+		case <-ctx.Done():
+			return &ReadyError{Err: ctx.Err(), Errors: errs}
+		}
+	}
+}
+
+//Resize changes pool Capacity and MinCapacity.
+//When pool grows new objects are created for waiting Get calls and to keep MinCapacity.
+//When pool shrinks idle objects are destroyed immediately and borrowed ones are destroyed on Release
+func (p *TypedPool[T]) Resize(capacity int, minCapacity int) error {
+	if err := validateCapacity(capacity, minCapacity); err != nil {
+		return err
+	}
+
+	if p.ctx.Err() == context.Canceled {
+		return ErrClosed
+	}
+
+	p.Lock()
+	p.capacity.Store(int64(capacity))
+	p.minCapacity.Store(int64(minCapacity))
+	p.Unlock()
+
+	//shrink
+	var itemsToDestroy []T
+
+	for delta := p.itemCollection.len() - capacity; delta > 0; delta-- {
+		item := p.itemCollection.acquireOldest()
+
+		if item == nil {
+			break
+		}
+		itemsToDestroy = append(itemsToDestroy, item.value)
+	}
+
+	p.destroy(itemsToDestroy, DestroyResized)
+
+	//grow
+	delta := p.itemCollection.lenWaiting()
+
+	if minCapacityDelta := minCapacity - p.itemCollection.len(); minCapacityDelta > delta {
+		delta = minCapacityDelta
+	}
+
+	if freeCapacity := capacity - p.itemCollection.len(); freeCapacity < delta {
+		delta = freeCapacity
+	}
+
+	for i := 0; i < delta; i++ {
+		go p.putItem(p.ctx)
+	}
+
+	return nil
+}
+
+//Invalidate marks all pool Objects as stale, e.g. after backend failover or credentials rotation.
+//Idle Objects are replaced by new ones, at most Config.InvalidateConcurrency at a time, and Invalidate returns when they are replaced.
+//Stale Objects which cannot be replaced are destroyed. Borrowed Objects are destroyed on Release and Get never hands out stale Objects
+func (p *TypedPool[T]) Invalidate() {
+	p.generation.Add(1)
+
+	var staleItems []*item[T]
+
+	for _, item := range p.itemCollection.acquireAll() {
+		if p.isStale(item) {
+			staleItems = append(staleItems, item)
+		} else {
+			p.release(item.value, false)
+		}
+	}
+
+	concurrency := p.config.InvalidateConcurrency
+	if concurrency == 0 {
+		concurrency = 1
+	}
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, concurrency)
+
+	for _, staleItem := range staleItems {
+		wg.Add(1)
+		semaphore <- struct{}{}
+
+		go func(item *item[T]) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			if !p.replace(item, DestroyInvalidated) {
+				p.destroy([]T{item.value}, DestroyInvalidated)
+			}
+		}(staleItem)
+	}
+
+	wg.Wait()
+}
+
+//CircuitState returns state of pool circuit breaker (see Config.CircuitBreaker)
+func (p *TypedPool[T]) CircuitState() CircuitState {
+	return p.breaker.currentState()
+}
+
+//Stats returns pool statistics snapshot
+func (p *TypedPool[T]) Stats() Stats {
+	stats := p.stats.snapshot()
+
+	stats.Total = p.itemCollection.len()
+	stats.Idle = p.itemCollection.lenIdle()
+	stats.Quarantined = p.itemCollection.lenQuarantined()
+	stats.Waiting = p.itemCollection.lenWaiting()
+	stats.InUse = stats.Total - stats.Idle - stats.Quarantined
+
+	return stats
+}
+
+//Close clears and closes pool
+func (p *TypedPool[T]) Close() error {
+	if p.itemCollection.lenBorrowed() > 0 {
+		return errors.New("pool cannot be closed - there are unreleased items")
+	}
+
+	p.cancel()
+
+	p.itemCollection.close()
+	items := p.itemCollection.getAll()
+	for _, item := range items {
+		p.destroyItem(item, DestroyPoolClosing)
+	}
+
+	return nil
+}
+
+func (p *TypedPool[T]) release(object T, updateReleaseTime bool) {
+	item := p.itemCollection.get(object)
+
+	if item != nil {
+		if updateReleaseTime {
+			item.release()
+		}
+
+		p.itemCollection.release(object)
+		p.recordSize()
+		p.notifyShutdown()
+	}
+}
+
+//quarantine keeps borrowed item out of pool for Config.QuarantineDuration (see checkQuarantine)
+func (p *TypedPool[T]) quarantine(item *item[T]) {
+	item.release()
+
+	p.itemCollection.quarantine(item.value, time.Now().UTC().Add(p.config.QuarantineDuration))
+	p.recordSize()
+	p.notifyShutdown()
+}
+
+//destroyBorrowed destroys borrowed object
+func (p *TypedPool[T]) destroyBorrowed(object T, reason DestroyReason) {
+	if item := p.itemCollection.get(object); item != nil {
+		p.metrics.ObserveBorrow(p.config.Name, item.borrowDuration())
+	}
+
+	p.destroy([]T{object}, reason)
+}
+
+//discardItem destroys created item which has not been added to collection
+func (p *TypedPool[T]) discardItem(item *item[T]) {
+	item.destroy()
+
+	if p.limiter != nil {
+		p.limiter.release()
+	}
+}
+
+//destroyItem destroys item which is already removed from collection
+func (p *TypedPool[T]) destroyItem(item *item[T], reason DestroyReason) {
+	item.destroy()
+
+	if p.limiter != nil {
+		p.limiter.release()
+	}
+
+	p.stats.destroys.Add(1)
+	p.metrics.ObserveDestroy(p.config.Name)
+	p.config.Hooks.onDestroy(item.object, reason)
+}
+
+func (p *TypedPool[T]) destroy(objectList []T, reason DestroyReason) {
+	isItemDestroyed := false
+
+	for _, object := range objectList {
+		if item := p.itemCollection.remove(object); item != nil {
+			p.destroyItem(item, reason)
+
+			isItemDestroyed = true
+		}
+	}
+
+	if isItemDestroyed {
+		p.itemsDestroyed()
+	}
+}
+
+//itemsDestroyed notifies pool routines that items have been removed from collection
+func (p *TypedPool[T]) itemsDestroyed() {
+	p.recordSize()
+	p.notifyShutdown()
+
+	//destroyed items free capacity for waiting Get calls
+	if p.itemCollection.lenWaiting() > 0 {
+		go p.putItem(p.ctx)
+	}
+
+	select {
+	case p.itemDestroyedCh <- true:
+		break
+	default:
+		break
+	}
+}
+
+func (p *TypedPool[T]) getIdleItem(ctx context.Context, waitCtx context.Context) (*item[T], error) {
+	createCtx := valueContext{Context: p.ctx, values: ctx}
+
+	//subscription must precede item creation to receive its error
+	createErr := p.createErrors.subscribe()
+
+	item, waiter := p.itemCollection.acquireOrWait()
+
+	if item != nil {
+		p.stats.hits.Add(1)
+		return item, nil
+	}
+
+	p.stats.misses.Add(1)
+
+	//no item can be created while circuit is open, so Get fails fast instead of waiting for Timeout
+	if p.breaker.currentState() == CircuitOpen {
+		p.cancelWait(waiter)
+		return nil, ErrCircuitOpen
+	}
+
+	p.recordSize()
+
+	if p.isInitialized.Load() {
+		go p.putItem(createCtx)
+	}
+
+	//waiting for released or created item or timeout
+	for {
+		select {
+		case item := <-waiter.itemCh:
+			return item, nil
+		case <-waitCtx.Done():
+			p.cancelWait(waiter)
+			p.stats.timeouts.Add(1)
+			if err := ctx.Err(); err != nil {
+				return nil, fmt.Errorf("waiting for pool item is interrupted: %w", err)
+			}
+			return nil, ErrTimeout
+		case <-p.ctx.Done():
+			p.cancelWait(waiter)
+			return nil, ErrClosed
+		case <-createErr.done:
+			if !p.config.KeepWaitingOnFactoryError {
+				p.cancelWait(waiter)
+				return nil, createErr.err
+			}
+			createErr = p.createErrors.subscribe()
+		}
+	}
+}
+
+//cancelWait removes waiter from queue. An item which has been already handed out to the waiter is passed to the next one
+func (p *TypedPool[T]) cancelWait(waiter *waiter[T]) {
+	if !p.itemCollection.cancelWait(waiter) {
+		item := <-waiter.itemCh
+		p.release(item.value, false)
+	}
+	p.recordSize()
+}
+
+func (p *TypedPool[T]) putItem(ctx context.Context) error {
+	if !p.canGrow() {
+		return nil
+	}
+
+	//total capacity is acquired before the lock, because eviction destroys items of other pools and calls their hooks
+	if p.limiter != nil && !p.limiter.acquire() {
+		err := fmt.Errorf("%w - total capacity of pools is exceeded", ErrExhausted)
+		p.createErrors.publish(err)
+		return err
+	}
+
+	item, isAdded, err := p.addItem(ctx)
+
+	if item == nil {
+		return err
+	}
+
+	p.config.Hooks.onCreate(item.object)
+
+	if isAdded {
+		p.release(item.value, true)
+	} else {
+		p.destroyItem(item, DestroyPoolClosing)
+	}
+	return nil
+}
+
+//canGrow returns false if pool is closed or its capacity is reached
+func (p *TypedPool[T]) canGrow() bool {
+	return p.ctx.Err() == nil && p.itemCollection.len() < int(p.capacity.Load())
+}
+
+//addItem creates a new item and adds it to collection as a borrowed one. Total capacity must be already acquired by the caller.
+//It returns false if item cannot be added because pool is closed
+func (p *TypedPool[T]) addItem(ctx context.Context) (*item[T], bool, error) {
+	p.Lock()
+	defer p.Unlock()
+
+	//pool could be closed or reach its capacity while total capacity has been acquired
+	if !p.canGrow() {
+		if p.limiter != nil {
+			p.limiter.release()
+		}
+		return nil, false, nil
+	}
+
+	item, err := p.createItem(ctx)
+
+	if err != nil {
+		if p.limiter != nil {
+			p.limiter.release()
+		}
+		p.createErrors.publish(err)
+		return nil, false, err
+	}
+
+	isAdded, err := p.itemCollection.put(item.value, item)
+
+	if err != nil {
+		p.discardItem(item)
+		p.createErrors.publish(err)
+		return nil, false, err
+	}
+
+	if !isAdded {
+		return item, false, nil
+	}
+
+	//we assume that pool is initialized when a first object has been added to pool collection
+	p.isInitialized.Store(true)
+
+	return item, true, nil
+}
+
+//fillMinCapacity creates items to keep MinCapacity and returns errors of failed creations
+func (p *TypedPool[T]) fillMinCapacity(ctx context.Context) []error {
+	//concurrent filling would create more than MinCapacity items
+	p.fillMutex.Lock()
+	defer p.fillMutex.Unlock()
+
+	delta := int(p.minCapacity.Load()) - p.itemCollection.len()
+
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	var errs []error
+
+	for i := 0; i < delta; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := p.putItem(ctx); err != nil {
+				mutex.Lock()
+				errs = append(errs, err)
+				mutex.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return errs
+}
+
+func (p *TypedPool[T]) keepMinCapacity() {
+	var retryCh <-chan time.Time
+	attempt := 0
+
+	refill := func() {
+		if len(p.fillMinCapacity(p.ctx)) == 0 || p.config.Backoff.Initial == 0 {
+			attempt = 0
+			retryCh = nil
+			return
+		}
+
+		attempt++
+		retryCh = time.After(p.config.Backoff.delay(attempt))
+	}
+
+	refill()
+
+	for {
+		select {
+		case <-p.itemDestroyedCh:
+			//failed creation is retried after backoff delay
+			if retryCh == nil {
+				refill()
+			}
+		case <-retryCh:
+			refill()
+		case <-p.ctx.Done():
+			return
+		}
+	}
+}
+
+//cleanUp clears inactive pool elements
+func (p *TypedPool[T]) cleanUp() {
+	if !p.config.isMaintained() {
+		return
+	}
+
+	ticker := time.NewTicker(p.config.ItemLifetimeCheckPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.checkIdleItems()
+			p.checkQuarantine()
+			p.checkLeaks()
+			p.checkLeases()
+		case <-p.ctx.Done():
+			return
+		}
+	}
+}
+
+//checkIdleItems destroys expired idle items and tests idle items (see Config.TestWhileIdle)
+func (p *TypedPool[T]) checkIdleItems() {
+	var itemsToDestroy []T
+	var staleItems []T
+	var itemsToRefresh []*item[T]
+	var itemsToTest []*item[T]
+
+	for _, item := range p.itemCollection.acquireAll() {
+		if p.isStale(item) {
+			staleItems = append(staleItems, item.value)
+			continue
+		}
+
+		//expirations and refreshes share the per-check limit, the rest of items are handled by the following checks
+		isExpirationAllowed := p.config.MaxExpirationsPerCheck == 0 || len(itemsToDestroy)+len(itemsToRefresh) < p.config.MaxExpirationsPerCheck
+
+		if isExpirationAllowed && !item.isActive() {
+			itemsToDestroy = append(itemsToDestroy, item.value)
+			p.stats.expirations.Add(1)
+			p.config.Hooks.onExpire(item.object)
+		} else if isExpirationAllowed && item.isAging() {
+			itemsToRefresh = append(itemsToRefresh, item)
+		} else if p.config.TestWhileIdle && (p.config.IdleTestsPerCheck == 0 || len(itemsToTest) < p.config.IdleTestsPerCheck) {
+			itemsToTest = append(itemsToTest, item)
+		} else {
+			p.release(item.value, false)
+		}
+	}
+
+	p.destroy(itemsToDestroy, DestroyExpired)
+	p.destroy(staleItems, DestroyInvalidated)
+
+	//items which are being refreshed are not available for Get.
+	//Replacements are created one by one, so a slow factory delays the following checks
+	for _, item := range itemsToRefresh {
+		p.refresh(item)
+	}
+
+	//items which are being tested are not available for Get
+	for _, item := range itemsToTest {
+		if err := p.testIdleItem(item); err != nil {
+			p.destroy([]T{item.value}, DestroyValidationFailed)
+		} else {
+			p.release(item.value, false)
+		}
+	}
+}
+
+//refresh replaces idle item which lifetime is about to expire with a new one (see Config.RefreshAhead)
+func (p *TypedPool[T]) refresh(item *item[T]) {
+	if !p.replace(item, DestroyRefreshed) {
+		p.release(item.value, false)
+	}
+}
+
+//replace creates a new item and puts it in place of the old one which is not idle, then the old item is destroyed.
+//It returns false if the new item cannot be created, the old item is left untouched in this case
+func (p *TypedPool[T]) replace(oldItem *item[T], reason DestroyReason) bool {
+	if p.limiter != nil && !p.limiter.acquire() {
+		return false
+	}
+
+	item, err := p.createItem(p.ctx)
+
+	if err != nil {
+		if p.limiter != nil {
+			p.limiter.release()
+		}
+		return false
+	}
+
+	isReplaced, err := p.itemCollection.replace(oldItem.value, item.value, item)
+
+	if err != nil {
+		p.discardItem(item)
+		return false
+	}
+
+	p.config.Hooks.onCreate(item.object)
+
+	//old item could be destroyed concurrently by Shutdown
+	if !isReplaced {
+		p.destroyItem(item, DestroyPoolClosing)
+		return false
+	}
+
+	p.release(item.value, false)
+	p.destroyItem(oldItem, reason)
+
+	return true
+}
+
+//checkQuarantine validates items which quarantine is over and returns them to pool
+func (p *TypedPool[T]) checkQuarantine() {
+	if p.config.QuarantineDuration == 0 {
+		return
+	}
+
+	for _, item := range p.itemCollection.unquarantine(time.Now().UTC()) {
+		if err := p.testIdleItem(item); err != nil {
+			p.destroy([]T{item.value}, DestroyValidationFailed)
+		} else {
+			p.release(item.value, false)
+		}
+	}
+}
+
+//checkLeaks reports items which are borrowed for more than Config.LeakThreshold
+func (p *TypedPool[T]) checkLeaks() {
+	if p.config.LeakThreshold == 0 {
+		return
+	}
+
+	for _, item := range p.itemCollection.getBorrowed() {
+		if leak, ok := item.leak(p.config.LeakThreshold); ok && item.reportLeak() {
+			p.config.Hooks.onLeak(leak)
+		}
+	}
+}
+
+func (p *TypedPool[T]) testIdleItem(item *item[T]) error {
+	ctx := p.ctx
+
+	if p.config.IdleTestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.config.IdleTestTimeout)
+		defer cancel()
+	}
+
+	return validate(ctx, item.object)
+}
+
+func (p *TypedPool[T]) createItem(ctx context.Context) (*item[T], error) {
+	var object Object
+
+	if !p.breaker.allow() {
+		return nil, ErrCircuitOpen
+	}
+
+	generation := p.generation.Load()
+	start := time.Now()
+
+	value, err := p.factory.Create(ctx)
+	duration := time.Since(start)
+
+	if err != nil {
+		p.breaker.failure()
+
+		err = &FactoryError{
+			Err:       err,
+			Attempts:  int(p.createAttempts.Add(1)),
+			StartTime: start,
+			Duration:  duration,
+		}
+	} else {
+		p.breaker.success()
+
+		object, err = p.checkObject(value)
+	}
+
+	p.metrics.ObserveCreate(p.config.Name, duration, err)
+
+	if err != nil {
+		p.stats.createFailures.Add(1)
+		return nil, err
+	}
+
+	p.createAttempts.Store(0)
+	p.stats.creates.Add(1)
+
+	maxLifetime := jitter(p.config.MaxLifetime, p.config.LifetimeJitter)
+
+	item := newItem(value, object, jitter(p.config.maxIdleTime(), p.config.LifetimeJitter), maxLifetime)
+	item.generation = generation
+
+	//refresh window is reduced by jitter in proportion to lifetime, so it stays shorter than the lifetime
+	if p.config.RefreshAhead > 0 {
+		item.refreshAhead = time.Duration(float64(p.config.RefreshAhead) * float64(maxLifetime) / float64(p.config.MaxLifetime))
+	}
+
+	return item, nil
+}
+
+//isStale returns true if item has been created before the last Invalidate call
+func (p *TypedPool[T]) isStale(item *item[T]) bool {
+	return item.generation < p.generation.Load()
+}
+
+//checkObject returns the Object which is held by value
+func (p *TypedPool[T]) checkObject(value T) (Object, error) {
+	object := p.unwrap(value)
+
+	if reflect.ValueOf(object).Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("%w - ggpool.Config.Factory must return object pointer", ErrInvalidObject)
+	}
+
+	poolObject, ok := object.(Object)
+	if !ok {
+		return nil, fmt.Errorf("%w - ggpool.Config.Factory must create object which implement ggpool.Object interface", ErrInvalidObject)
+	}
+
+	return poolObject, nil
+}
+
+//evictIdle destroys an idle item to free capacity shared with other pools.
+//Pool doesn't evict items when its length is not more than MinCapacity
+func (p *TypedPool[T]) evictIdle() bool {
+	if p.itemCollection.len() <= int(p.minCapacity.Load()) {
+		return false
+	}
+
+	item := p.itemCollection.acquireOldest()
+
+	if item == nil {
+		return false
+	}
+
+	p.destroy([]T{item.value}, DestroyEvicted)
+
+	return true
+}
+
+//notifyShutdown wakes up Shutdown which waits for borrowed items
+func (p *TypedPool[T]) notifyShutdown() {
+	select {
+	case p.shutdownCh <- true:
+		break
+	default:
+		break
+	}
+}
+
+func (p *TypedPool[T]) recordSize() {
+	p.metrics.SetSize(p.config.Name, p.itemCollection.len(), p.itemCollection.lenIdle(), p.itemCollection.lenWaiting())
+}
+
+//validate checks object if it implements Validator interface
+func validate(ctx context.Context, object Object) error {
+	if validator, ok := object.(Validator); ok {
+		return validator.Validate(ctx)
+	}
+	return nil
+}
+
+func activate(ctx context.Context, object Object) error {
+	if activator, ok := object.(Activator); ok {
+		return activator.Activate(ctx)
+	}
+	return nil
+}
+
+//passivate resets Object (see Resetter) and passivates it (see Passivator)
+func passivate(ctx context.Context, object Object) error {
+	if resetter, ok := object.(Resetter); ok {
+		resetter.Reset()
+	}
+
+	if passivator, ok := object.(Passivator); ok {
+		return passivator.Passivate(ctx)
+	}
+	return nil
+}